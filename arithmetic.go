@@ -0,0 +1,514 @@
+// Copyright (C) 2022  Alexander Staudt
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// This file implements date arithmetic in the units of each calendar.
+// Adding months or years may yield a day that does not exist in the
+// resulting month (e.g. 30 Heshvan in a year with a short Heshvan), which is
+// resolved according to an OverflowPolicy.
+
+package libcalendar
+
+import (
+	"errors"
+	"math"
+	"math/big"
+)
+
+// OverflowPolicy determines how date arithmetic resolves days or months that
+// do not exist in the resulting year.
+type OverflowPolicy int
+
+const (
+	// Clamp replaces a non-existent day by the last day of the month, and a
+	// non-existent leap month by the corresponding ordinary month.
+	Clamp OverflowPolicy = iota
+	// RollOver carries excess days over into the following month. Like Clamp,
+	// it replaces a non-existent leap month by the ordinary month.
+	RollOver
+	// Strict returns ErrDayOutOfRange or ErrMonthOutOfRange instead of
+	// adjusting the date.
+	Strict
+)
+
+var (
+	// ErrDayOutOfRange is returned by date arithmetic under the Strict policy
+	// if the resulting month does not have the requested day.
+	ErrDayOutOfRange = errors.New("libcalendar: day out of range")
+	// ErrMonthOutOfRange is returned by date arithmetic under the Strict
+	// policy if the resulting year does not have the requested month.
+	ErrMonthOutOfRange = errors.New("libcalendar: month out of range")
+)
+
+// resolveDay applies an overflow policy to a day of a month with lastDay
+// days. firstDay is the absolute date of the first day of the month. It
+// returns the day's absolute date.
+func resolveDay(firstDay, lastDay, day float64, policy OverflowPolicy) (absoluteDate float64, err error) {
+	if day <= lastDay {
+		return firstDay + day - 1, nil
+	}
+	switch policy {
+	case Clamp:
+		return firstDay + lastDay - 1, nil
+	case RollOver:
+		return firstDay + day - 1, nil
+	default:
+		return math.NaN(), ErrDayOutOfRange
+	}
+}
+
+// wholeMonths returns the number of whole months between two dates, given
+// the difference of their month indices and their days of month.
+func wholeMonths(months, day1, day2 float64) float64 {
+	switch {
+	case months > 0 && day2 < day1:
+		return months - 1
+	case months < 0 && day2 > day1:
+		return months + 1
+	default:
+		return months
+	}
+}
+
+// Gregorian calendar
+
+// AddDays returns the date n days after its receiver.
+func (d GregorianDate) AddDays(n float64) GregorianDate {
+	return GregorianFromAbsolute(AbsoluteFromGregorian(d) + n)
+}
+
+// AddMonths returns the date n months after its receiver.
+func (d GregorianDate) AddMonths(n float64, policy OverflowPolicy) (GregorianDate, error) {
+	index := 12*d.Year + d.Month - 1 + n
+	year := math.Floor(index / 12)
+	month := mod(index, 12) + 1
+	date, err := resolveDay(
+		AbsoluteFromGregorian(GregorianDate{year, month, 1}),
+		LastDayOfGregorianMonth(month, year), d.Day, policy)
+	if err != nil {
+		return GregorianDate{}, err
+	}
+	return GregorianFromAbsolute(date), nil
+}
+
+// AddYears returns the date n years after its receiver.
+func (d GregorianDate) AddYears(n float64, policy OverflowPolicy) (GregorianDate, error) {
+	return d.AddMonths(12*n, policy)
+}
+
+// DaysBetween returns the number of days from its receiver to other.
+func (d GregorianDate) DaysBetween(other GregorianDate) float64 {
+	return AbsoluteFromGregorian(other) - AbsoluteFromGregorian(d)
+}
+
+// MonthsBetween returns the number of whole months from its receiver to other.
+func (d GregorianDate) MonthsBetween(other GregorianDate) float64 {
+	return wholeMonths(12*(other.Year-d.Year)+other.Month-d.Month, d.Day, other.Day)
+}
+
+// Julian calendar
+
+// AddDays returns the date n days after its receiver.
+func (d JulianDate) AddDays(n float64) JulianDate {
+	return JulianFromAbsolute(AbsoluteFromJulian(d) + n)
+}
+
+// AddMonths returns the date n months after its receiver.
+func (d JulianDate) AddMonths(n float64, policy OverflowPolicy) (JulianDate, error) {
+	index := 12*d.Year + d.Month - 1 + n
+	year := math.Floor(index / 12)
+	month := mod(index, 12) + 1
+	date, err := resolveDay(
+		AbsoluteFromJulian(JulianDate{year, month, 1}),
+		LastDayOfJulianMonth(month, year), d.Day, policy)
+	if err != nil {
+		return JulianDate{}, err
+	}
+	return JulianFromAbsolute(date), nil
+}
+
+// AddYears returns the date n years after its receiver.
+func (d JulianDate) AddYears(n float64, policy OverflowPolicy) (JulianDate, error) {
+	return d.AddMonths(12*n, policy)
+}
+
+// DaysBetween returns the number of days from its receiver to other.
+func (d JulianDate) DaysBetween(other JulianDate) float64 {
+	return AbsoluteFromJulian(other) - AbsoluteFromJulian(d)
+}
+
+// MonthsBetween returns the number of whole months from its receiver to other.
+func (d JulianDate) MonthsBetween(other JulianDate) float64 {
+	return wholeMonths(12*(other.Year-d.Year)+other.Month-d.Month, d.Day, other.Day)
+}
+
+// ISO calendar
+
+// WeeksInIsoYear returns the number of weeks (52 or 53) of a given ISO year.
+func WeeksInIsoYear(year float64) float64 {
+	return (AbsoluteFromIso(IsoDate{year + 1, 1, 1}) - AbsoluteFromIso(IsoDate{year, 1, 1})) / 7
+}
+
+// AddDays returns the date n days after its receiver.
+func (d IsoDate) AddDays(n float64) IsoDate {
+	return IsoFromAbsolute(AbsoluteFromIso(d) + n)
+}
+
+// AddWeeks returns the date n weeks after its receiver.
+func (d IsoDate) AddWeeks(n float64) IsoDate {
+	return d.AddDays(7 * n)
+}
+
+// AddYears returns the date n years after its receiver, keeping week and day.
+// Week 53 does not exist in years with 52 weeks.
+func (d IsoDate) AddYears(n float64, policy OverflowPolicy) (IsoDate, error) {
+	year := d.Year + n
+	weeks := WeeksInIsoYear(year)
+	if d.Week <= weeks || policy == RollOver {
+		return IsoFromAbsolute(AbsoluteFromIso(IsoDate{year, d.Week, d.Day})), nil
+	}
+	if policy == Clamp {
+		return IsoDate{year, weeks, d.Day}, nil
+	}
+	return IsoDate{}, ErrDayOutOfRange
+}
+
+// DaysBetween returns the number of days from its receiver to other.
+func (d IsoDate) DaysBetween(other IsoDate) float64 {
+	return AbsoluteFromIso(other) - AbsoluteFromIso(d)
+}
+
+// WeeksBetween returns the number of whole weeks from its receiver to other.
+func (d IsoDate) WeeksBetween(other IsoDate) float64 {
+	days := d.DaysBetween(other)
+	if days < 0 {
+		return -math.Floor(-days / 7)
+	}
+	return math.Floor(days / 7)
+}
+
+// Islamic calendar
+
+// AddDays returns the date n days after its receiver.
+func (d IslamicDate) AddDays(n float64) IslamicDate {
+	return IslamicFromAbsolute(AbsoluteFromIslamic(d) + n)
+}
+
+// AddMonths returns the date n months after its receiver.
+func (d IslamicDate) AddMonths(n float64, policy OverflowPolicy) (IslamicDate, error) {
+	index := 12*d.Year + d.Month - 1 + n
+	year := math.Floor(index / 12)
+	month := mod(index, 12) + 1
+	date, err := resolveDay(
+		AbsoluteFromIslamic(IslamicDate{year, month, 1}),
+		LastDayOfIslamicMonth(month, year), d.Day, policy)
+	if err != nil {
+		return IslamicDate{}, err
+	}
+	return IslamicFromAbsolute(date), nil
+}
+
+// AddYears returns the date n years after its receiver.
+func (d IslamicDate) AddYears(n float64, policy OverflowPolicy) (IslamicDate, error) {
+	return d.AddMonths(12*n, policy)
+}
+
+// DaysBetween returns the number of days from its receiver to other.
+func (d IslamicDate) DaysBetween(other IslamicDate) float64 {
+	return AbsoluteFromIslamic(other) - AbsoluteFromIslamic(d)
+}
+
+// MonthsBetween returns the number of whole months from its receiver to other.
+func (d IslamicDate) MonthsBetween(other IslamicDate) float64 {
+	return wholeMonths(12*(other.Year-d.Year)+other.Month-d.Month, d.Day, other.Day)
+}
+
+// Hebrew calendar
+
+// hebrewMonthsBefore returns the number of months elapsed before Tishri of a
+// given Hebrew year.
+func hebrewMonthsBefore(year float64) float64 {
+	return math.Floor((235*year - 234) / 19)
+}
+
+// hebrewMonthIndex returns the number of months elapsed before a given month
+// of a given Hebrew year. Months are counted from Tishri, the first month of
+// the civil year, so that Adar II follows Adar I.
+func hebrewMonthIndex(month, year float64) float64 {
+	if month >= tishri {
+		return hebrewMonthsBefore(year) + month - tishri
+	}
+	return hebrewMonthsBefore(year) + month - tishri + LastMonthOfHebrewYear(year)
+}
+
+// hebrewMonthFromIndex is the inverse of hebrewMonthIndex.
+func hebrewMonthFromIndex(index float64) (month, year float64) {
+	year = math.Floor(19*index/235) + 1
+	for hebrewMonthsBefore(year+1) <= index {
+		year++
+	}
+	for hebrewMonthsBefore(year) > index {
+		year--
+	}
+	ordinal := index - hebrewMonthsBefore(year)
+	if ordinal < LastMonthOfHebrewYear(year)-6 {
+		return tishri + ordinal, year
+	}
+	return ordinal - (LastMonthOfHebrewYear(year) - 6) + 1, year
+}
+
+// AddDays returns the date n days after its receiver.
+func (d HebrewDate) AddDays(n float64) HebrewDate {
+	return HebrewFromAbsolute(AbsoluteFromHebrew(d) + n)
+}
+
+// AddMonths returns the date n months after its receiver. Months are counted
+// as they occur, i.e. in leap years Adar I and Adar II count as two months.
+func (d HebrewDate) AddMonths(n float64, policy OverflowPolicy) (HebrewDate, error) {
+	month, year := hebrewMonthFromIndex(hebrewMonthIndex(d.Month, d.Year) + n)
+	date, err := resolveDay(
+		AbsoluteFromHebrew(HebrewDate{year, month, 1}),
+		LastDayOfHebrewMonth(month, year), d.Day, policy)
+	if err != nil {
+		return HebrewDate{}, err
+	}
+	return HebrewFromAbsolute(date), nil
+}
+
+// AddYears returns the date n years after its receiver, keeping its month.
+// As for HebrewBirthday, Adar of a common year corresponds to Adar II of a
+// leap year, while Adar I and Adar II of a leap year correspond to Adar of a
+// common year.
+func (d HebrewDate) AddYears(n float64, policy OverflowPolicy) (HebrewDate, error) {
+	year := d.Year + n
+	month := d.Month
+	if month == LastMonthOfHebrewYear(d.Year) || month == adar_ii {
+		month = LastMonthOfHebrewYear(year)
+	}
+	date, err := resolveDay(
+		AbsoluteFromHebrew(HebrewDate{year, month, 1}),
+		LastDayOfHebrewMonth(month, year), d.Day, policy)
+	if err != nil {
+		return HebrewDate{}, err
+	}
+	return HebrewFromAbsolute(date), nil
+}
+
+// DaysBetween returns the number of days from its receiver to other.
+func (d HebrewDate) DaysBetween(other HebrewDate) float64 {
+	return AbsoluteFromHebrew(other) - AbsoluteFromHebrew(d)
+}
+
+// MonthsBetween returns the number of whole months from its receiver to other.
+func (d HebrewDate) MonthsBetween(other HebrewDate) float64 {
+	return wholeMonths(
+		hebrewMonthIndex(other.Month, other.Year)-hebrewMonthIndex(d.Month, d.Year),
+		d.Day, other.Day)
+}
+
+// French Revolutionary calendar
+
+// AddDays returns the date n days after its receiver.
+func (d FrenchDate) AddDays(n float64) FrenchDate {
+	return FrenchFromAbsolute(AbsoluteFromFrench(d) + n)
+}
+
+// AddMonths returns the date n months after its receiver. The sansculottides
+// count as a (short) thirteenth month.
+func (d FrenchDate) AddMonths(n float64, policy OverflowPolicy) (FrenchDate, error) {
+	index := 13*d.Year + d.Month - 1 + n
+	year := math.Floor(index / 13)
+	month := mod(index, 13) + 1
+	date, err := resolveDay(
+		AbsoluteFromFrench(FrenchDate{year, month, 1}),
+		FrenchLastDayOfMonth(month, year), d.Day, policy)
+	if err != nil {
+		return FrenchDate{}, err
+	}
+	return FrenchFromAbsolute(date), nil
+}
+
+// AddYears returns the date n years after its receiver.
+func (d FrenchDate) AddYears(n float64, policy OverflowPolicy) (FrenchDate, error) {
+	return d.AddMonths(13*n, policy)
+}
+
+// DaysBetween returns the number of days from its receiver to other.
+func (d FrenchDate) DaysBetween(other FrenchDate) float64 {
+	return AbsoluteFromFrench(other) - AbsoluteFromFrench(d)
+}
+
+// MonthsBetween returns the number of whole months from its receiver to other.
+func (d FrenchDate) MonthsBetween(other FrenchDate) float64 {
+	return wholeMonths(13*(other.Year-d.Year)+other.Month-d.Month, d.Day, other.Day)
+}
+
+// Mayan long count
+
+// AddDays returns the date n days after its receiver.
+func (d MayanLongCount) AddDays(n float64) MayanLongCount {
+	return MayanLongCountFromAbsolute(AbsoluteFromMayanLongCount(d) + n)
+}
+
+// DaysBetween returns the number of days from its receiver to other.
+func (d MayanLongCount) DaysBetween(other MayanLongCount) float64 {
+	return AbsoluteFromMayanLongCount(other) - AbsoluteFromMayanLongCount(d)
+}
+
+// Old Hindu solar calendar
+
+// LastDayOfOldHinduSolarMonth returns the last day (number of days) of a
+// given Old Hindu solar month.
+func LastDayOfOldHinduSolarMonth(month, year float64) (day float64) {
+	next := 12*year + month
+	return AbsoluteFromOldHinduSolar(OldHinduSolarDate{math.Floor(next / 12), mod(next, 12) + 1, 1}) -
+		AbsoluteFromOldHinduSolar(OldHinduSolarDate{year, month, 1})
+}
+
+// AddDays returns the date n days after its receiver.
+func (d OldHinduSolarDate) AddDays(n float64) OldHinduSolarDate {
+	return OldHinduSolarFromAbsolute(AbsoluteFromOldHinduSolar(d) + n)
+}
+
+// AddMonths returns the date n months after its receiver.
+func (d OldHinduSolarDate) AddMonths(n float64, policy OverflowPolicy) (OldHinduSolarDate, error) {
+	index := 12*d.Year + d.Month - 1 + n
+	year := math.Floor(index / 12)
+	month := mod(index, 12) + 1
+	date, err := resolveDay(
+		AbsoluteFromOldHinduSolar(OldHinduSolarDate{year, month, 1}),
+		LastDayOfOldHinduSolarMonth(month, year), d.Day, policy)
+	if err != nil {
+		return OldHinduSolarDate{}, err
+	}
+	return OldHinduSolarFromAbsolute(date), nil
+}
+
+// AddYears returns the date n years after its receiver.
+func (d OldHinduSolarDate) AddYears(n float64, policy OverflowPolicy) (OldHinduSolarDate, error) {
+	return d.AddMonths(12*n, policy)
+}
+
+// DaysBetween returns the number of days from its receiver to other.
+func (d OldHinduSolarDate) DaysBetween(other OldHinduSolarDate) float64 {
+	return AbsoluteFromOldHinduSolar(other) - AbsoluteFromOldHinduSolar(d)
+}
+
+// MonthsBetween returns the number of whole months from its receiver to other.
+func (d OldHinduSolarDate) MonthsBetween(other OldHinduSolarDate) float64 {
+	return wholeMonths(12*(other.Year-d.Year)+other.Month-d.Month, d.Day, other.Day)
+}
+
+// Old Hindu lunar calendar
+
+// oldHinduLunarMonthStart returns the moment (in days since the Hindu epoch)
+// of the new moon beginning the lunar month of a given absolute date.
+func oldHinduLunarMonthStart(absoluteDate float64) *big.Rat {
+	return NewMoon(oldHinduDays(OldHinduSunrise(absoluteDate)))
+}
+
+// oldHinduLunarMonthOf returns the Old Hindu lunar year, month and leap month
+// flag of the month beginning with a given new moon.
+func oldHinduLunarMonthOf(newMoon *big.Rat) OldHinduLunarDate {
+	// the second sunrise after the new moon is safely within its month
+	date := floorf(newMoon) - 1132959 + 2
+	d := OldHinduLunarFromAbsolute(date)
+	d.Day = 0
+	return d
+}
+
+// oldHinduLunarDay returns the absolute date of a given day of an Old Hindu
+// lunar month, resolving expunged days according to policy: Clamp uses the
+// preceding day, RollOver the following day.
+func oldHinduLunarDay(d OldHinduLunarDate, policy OverflowPolicy) (float64, error) {
+	date := AbsoluteFromOldHinduLunar(d)
+	if !math.IsNaN(date) {
+		return date, nil
+	}
+	step := -1.0
+	switch policy {
+	case Strict:
+		return math.NaN(), ErrDayOutOfRange
+	case RollOver:
+		step = 1
+	}
+	for day := d.Day + step; day >= 1 && day <= 30; day += step {
+		if date := AbsoluteFromOldHinduLunar(OldHinduLunarDate{d.Year, d.Month, d.LeapMonth, day}); !math.IsNaN(date) {
+			return date, nil
+		}
+	}
+	return math.NaN(), ErrDayOutOfRange
+}
+
+// AddDays returns the date n days after its receiver.
+func (d OldHinduLunarDate) AddDays(n float64) OldHinduLunarDate {
+	return OldHinduLunarFromAbsolute(AbsoluteFromOldHinduLunar(d) + n)
+}
+
+// AddMonths returns the date n lunar months after its receiver. Leap months
+// are counted as they occur. Days that are expunged in the resulting month
+// are resolved according to policy.
+func (d OldHinduLunarDate) AddMonths(n float64, policy OverflowPolicy) (OldHinduLunarDate, error) {
+	start := oldHinduLunarMonthStart(AbsoluteFromOldHinduLunar(d))
+	target := oldHinduLunarMonthOf(add(start, mult(big.NewRat(int64(n), 1), LunarSynodicMonth)))
+	target.Day = d.Day
+	date, err := oldHinduLunarDay(target, policy)
+	if err != nil {
+		return OldHinduLunarDate{}, err
+	}
+	return OldHinduLunarFromAbsolute(date), nil
+}
+
+// AddYears returns the date n years after its receiver, keeping its month. If
+// the receiver falls in a leap month that does not recur in the resulting
+// year, the ordinary month of the same name is used, unless policy is Strict.
+func (d OldHinduLunarDate) AddYears(n float64, policy OverflowPolicy) (OldHinduLunarDate, error) {
+	target := OldHinduLunarDate{d.Year + n, d.Month, d.LeapMonth, d.Day}
+	if d.LeapMonth && !oldHinduLunarHasLeapMonth(target.Year, target.Month) {
+		if policy == Strict {
+			return OldHinduLunarDate{}, ErrMonthOutOfRange
+		}
+		target.LeapMonth = false
+	}
+	date, err := oldHinduLunarDay(target, policy)
+	if err != nil {
+		return OldHinduLunarDate{}, err
+	}
+	return OldHinduLunarFromAbsolute(date), nil
+}
+
+// oldHinduLunarHasLeapMonth returns true if a given Old Hindu lunar year has
+// a leap month preceding a given month.
+func oldHinduLunarHasLeapMonth(year, month float64) bool {
+	for day := 1.0; day <= 30; day++ {
+		if !math.IsNaN(AbsoluteFromOldHinduLunar(OldHinduLunarDate{year, month, true, day})) {
+			return true
+		}
+	}
+	return false
+}
+
+// DaysBetween returns the number of days from its receiver to other.
+func (d OldHinduLunarDate) DaysBetween(other OldHinduLunarDate) float64 {
+	return AbsoluteFromOldHinduLunar(other) - AbsoluteFromOldHinduLunar(d)
+}
+
+// MonthsBetween returns the number of whole lunar months from its receiver to
+// other, counting leap months.
+func (d OldHinduLunarDate) MonthsBetween(other OldHinduLunarDate) float64 {
+	start := oldHinduLunarMonthStart(AbsoluteFromOldHinduLunar(d))
+	end := oldHinduLunarMonthStart(AbsoluteFromOldHinduLunar(other))
+	months, _ := div(sub(end, start), LunarSynodicMonth).Float64()
+	return wholeMonths(math.Round(months), d.Day, other.Day)
+}
@@ -0,0 +1,175 @@
+// Copyright (C) 2022  Alexander Staudt
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package libcalendar
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestGregorianAddMonths(t *testing.T) {
+	tests := []struct {
+		date    GregorianDate
+		n       float64
+		policy  OverflowPolicy
+		want    GregorianDate
+		wantErr error
+	}{
+		{GregorianDate{2022, 1, 31}, 1, Clamp, GregorianDate{2022, 2, 28}, nil},
+		{GregorianDate{2022, 1, 31}, 1, RollOver, GregorianDate{2022, 3, 3}, nil},
+		{GregorianDate{2022, 1, 31}, 1, Strict, GregorianDate{}, ErrDayOutOfRange},
+		{GregorianDate{2022, 11, 15}, 3, Strict, GregorianDate{2023, 2, 15}, nil},
+		{GregorianDate{2022, 1, 15}, -1, Strict, GregorianDate{2021, 12, 15}, nil},
+		{GregorianDate{2024, 2, 29}, 12, Clamp, GregorianDate{2025, 2, 28}, nil},
+	}
+
+	for _, tt := range tests {
+		testname := fmt.Sprintf("%v %+.0f", tt.date, tt.n)
+		t.Run(testname, func(t *testing.T) {
+			got, err := tt.date.AddMonths(tt.n, tt.policy)
+			t.Logf("got %v, want %v", got, tt.want)
+			if got != tt.want || err != tt.wantErr {
+				t.Errorf("got %v (%v), want %v (%v)", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHebrewAddMonths(t *testing.T) {
+	tests := []struct {
+		date    HebrewDate
+		n       float64
+		policy  OverflowPolicy
+		want    HebrewDate
+		wantErr error
+	}{
+		// 5784 is a leap year: Shevat, Adar I, Adar II, Nisan
+		{HebrewDate{5784, shevat, 10}, 1, Strict, HebrewDate{5784, adar, 10}, nil},
+		{HebrewDate{5784, shevat, 10}, 2, Strict, HebrewDate{5784, adar_ii, 10}, nil},
+		{HebrewDate{5784, shevat, 10}, 3, Strict, HebrewDate{5784, nisan, 10}, nil},
+		// 5783 is a common year: Shevat, Adar, Nisan
+		{HebrewDate{5783, shevat, 10}, 2, Strict, HebrewDate{5783, nisan, 10}, nil},
+		{HebrewDate{5783, elul, 29}, 1, Strict, HebrewDate{5784, tishri, 29}, nil},
+		{HebrewDate{5784, adar, 30}, 1, Clamp, HebrewDate{5784, adar_ii, 29}, nil},
+		{HebrewDate{5784, adar, 30}, 1, RollOver, HebrewDate{5784, nisan, 1}, nil},
+		{HebrewDate{5784, adar, 30}, 1, Strict, HebrewDate{}, ErrDayOutOfRange},
+		{HebrewDate{5784, tishri, 1}, -25, Strict, HebrewDate{5782, tishri, 1}, nil},
+	}
+
+	for _, tt := range tests {
+		testname := fmt.Sprintf("%v %+.0f", tt.date, tt.n)
+		t.Run(testname, func(t *testing.T) {
+			got, err := tt.date.AddMonths(tt.n, tt.policy)
+			t.Logf("got %v, want %v", got, tt.want)
+			if got != tt.want || err != tt.wantErr {
+				t.Errorf("got %v (%v), want %v (%v)", got, err, tt.want, tt.wantErr)
+			}
+			if err == nil && tt.policy == Strict {
+				if back := tt.date.MonthsBetween(got); back != tt.n {
+					t.Errorf("MonthsBetween: got %v, want %v", back, tt.n)
+				}
+			}
+		})
+	}
+}
+
+func TestHebrewAddYears(t *testing.T) {
+	tests := []struct {
+		date HebrewDate
+		n    float64
+		want HebrewDate
+	}{
+		{HebrewDate{5783, adar, 14}, 1, HebrewDate{5784, adar_ii, 14}},
+		{HebrewDate{5784, adar_ii, 14}, 1, HebrewDate{5785, adar, 14}},
+		{HebrewDate{5784, adar, 14}, 1, HebrewDate{5785, adar, 14}},
+		{HebrewDate{5784, adar, 14}, 3, HebrewDate{5787, adar, 14}},
+		{HebrewDate{5783, nisan, 15}, 1, HebrewDate{5784, nisan, 15}},
+	}
+
+	for _, tt := range tests {
+		testname := fmt.Sprintf("%v %+.0f", tt.date, tt.n)
+		t.Run(testname, func(t *testing.T) {
+			got, err := tt.date.AddYears(tt.n, Strict)
+			t.Logf("got %v, want %v", got, tt.want)
+			if got != tt.want || err != nil {
+				t.Errorf("got %v (%v), want %v", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestFrenchAddMonths(t *testing.T) {
+	tests := []struct {
+		date   FrenchDate
+		n      float64
+		policy OverflowPolicy
+		want   FrenchDate
+	}{
+		{FrenchDate{2, fructidor, 30}, 1, Clamp, FrenchDate{2, 13, 5}},
+		{FrenchDate{3, fructidor, 30}, 1, Clamp, FrenchDate{3, 13, 6}},
+		{FrenchDate{2, fructidor, 30}, 1, RollOver, FrenchDate{3, vendémiaire, 25}},
+		{FrenchDate{2, 13, 3}, 1, Strict, FrenchDate{3, vendémiaire, 3}},
+	}
+
+	for _, tt := range tests {
+		testname := fmt.Sprintf("%v %+.0f", tt.date, tt.n)
+		t.Run(testname, func(t *testing.T) {
+			got, err := tt.date.AddMonths(tt.n, tt.policy)
+			t.Logf("got %v, want %v", got, tt.want)
+			if got != tt.want || err != nil {
+				t.Errorf("got %v (%v), want %v", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestOldHinduLunarAddMonths(t *testing.T) {
+	for _, rd := range []float64{710000, 720000, 738321} {
+		d := OldHinduLunarFromAbsolute(rd)
+		for _, n := range []float64{1, 12, -5} {
+			testname := fmt.Sprintf("%v %+.0f", d, n)
+			t.Run(testname, func(t *testing.T) {
+				got, err := d.AddMonths(n, Clamp)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if months := d.MonthsBetween(got); got.Day == d.Day && months != n {
+					t.Errorf("MonthsBetween: got %v, want %v", months, n)
+				}
+				if days := d.DaysBetween(got); days/n < 27 || days/n > 32 {
+					t.Errorf("got %v days for %v months", days, n)
+				}
+			})
+		}
+	}
+}
+
+func TestWeeksInIsoYear(t *testing.T) {
+	tests := []struct {
+		year float64
+		want float64
+	}{
+		{2015, 53}, {2020, 53}, {2021, 52}, {2022, 52},
+	}
+	for _, tt := range tests {
+		testname := fmt.Sprintf("%.0f", tt.year)
+		t.Run(testname, func(t *testing.T) {
+			if got := WeeksInIsoYear(tt.year); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,232 @@
+// Copyright (C) 2022  Alexander Staudt
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// This file implements the times of sunrise, sunset and twilight, using the
+// solar position algorithms of the NOAA Solar Calculator (after Jean Meeus,
+// "Astronomical Algorithms", 1991), and the times of new moons, after Meeus's
+// chapter on the phases of the moon. Results are accurate to about a minute
+// for latitudes between ±72°.
+
+package libcalendar
+
+import "math"
+
+// radians converts degrees to radians.
+func radians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
+
+// degrees converts radians to degrees.
+func degrees(radians float64) float64 {
+	return radians * 180 / math.Pi
+}
+
+// solarPosition returns the apparent longitude and the declination of the
+// sun (in degrees) and the equation of time (in days) at a given universal
+// moment.
+func solarPosition(m Moment) (longitude, declination, equationOfTime float64) {
+	c := (JulianDayFromAbsolute(float64(m)) - 2451545) / 36525 // Julian centuries since J2000
+	meanLongitude := radians(mod(280.46646+c*(36000.76983+c*0.0003032), 360))
+	meanAnomaly := radians(357.52911 + c*(35999.05029-0.0001537*c))
+	eccentricity := 0.016708634 - c*(0.000042037+0.0000001267*c)
+	center := math.Sin(meanAnomaly)*(1.914602-c*(0.004817+0.000014*c)) +
+		math.Sin(2*meanAnomaly)*(0.019993-0.000101*c) +
+		math.Sin(3*meanAnomaly)*0.000289
+	omega := radians(125.04 - 1934.136*c)
+	lambda := radians(degrees(meanLongitude) + center - 0.00569 - 0.00478*math.Sin(omega))
+	obliquity := radians(23 + (26+(21.448-c*(46.815+c*(0.00059-c*0.001813)))/60)/60 +
+		0.00256*math.Cos(omega))
+	longitude = mod(degrees(lambda), 360)
+	declination = degrees(math.Asin(math.Sin(obliquity) * math.Sin(lambda)))
+
+	y := math.Pow(math.Tan(obliquity/2), 2)
+	equationOfTime = (y*math.Sin(2*meanLongitude) -
+		2*eccentricity*math.Sin(meanAnomaly) +
+		4*eccentricity*y*math.Sin(meanAnomaly)*math.Cos(2*meanLongitude) -
+		0.5*y*y*math.Sin(4*meanLongitude) -
+		1.25*eccentricity*eccentricity*math.Sin(2*meanAnomaly)) / (2 * math.Pi)
+	return longitude, declination, equationOfTime
+}
+
+// ApparentSolarLongitude returns the apparent longitude of the sun (in
+// degrees) at a given universal moment, e.g. 0 at the March equinox.
+func ApparentSolarLongitude(m Moment) float64 {
+	longitude, _, _ := solarPosition(m)
+	return longitude
+}
+
+// SolarNoon returns the moment, in standard time, at which the sun crosses
+// the meridian of a location on a given absolute date.
+func SolarNoon(absoluteDate float64, l Location) Moment {
+	noon := UniversalFromLocal(Moment(absoluteDate+0.5), l)
+	for i := 0; i < 2; i++ {
+		_, _, equationOfTime := solarPosition(noon)
+		noon = UniversalFromLocal(Moment(absoluteDate+0.5-equationOfTime), l)
+	}
+	return StandardFromUniversal(noon, l)
+}
+
+// sunDepression returns the universal moment, on a given absolute date, at
+// which the center of the sun is `alpha` degrees below the horizon of a
+// location, in the morning or evening. It returns false if the sun does not
+// reach that depression on that date.
+func sunDepression(absoluteDate float64, l Location, alpha float64, morning bool) (Moment, bool) {
+	sign := 1.0
+	if morning {
+		sign = -1
+	}
+	approx := UniversalFromLocal(Moment(absoluteDate+0.5+sign*0.25), l)
+	for i := 0; i < 3; i++ {
+		_, declination, equationOfTime := solarPosition(approx)
+		cosHourAngle := (math.Sin(radians(-alpha)) -
+			math.Sin(radians(l.Latitude))*math.Sin(radians(declination))) /
+			(math.Cos(radians(l.Latitude)) * math.Cos(radians(declination)))
+		if cosHourAngle < -1 || cosHourAngle > 1 {
+			return 0, false
+		}
+		hourAngle := degrees(math.Acos(cosHourAngle)) / 360
+		approx = UniversalFromLocal(Moment(absoluteDate+0.5-equationOfTime+sign*hourAngle), l)
+	}
+	return approx, true
+}
+
+// Dawn returns the moment, in standard time, at which the center of the sun
+// is `alpha` degrees below the horizon in the morning of a given absolute date
+// at a location. It returns false if the sun does not reach that depression,
+// e.g. during polar days and nights.
+func Dawn(absoluteDate float64, l Location, alpha float64) (Moment, bool) {
+	m, ok := sunDepression(absoluteDate, l, alpha, true)
+	if !ok {
+		return 0, false
+	}
+	return StandardFromUniversal(m, l), true
+}
+
+// Dusk returns the moment, in standard time, at which the center of the sun
+// is `alpha` degrees below the horizon in the evening of a given absolute
+// date at a location. It returns false if the sun does not reach that
+// depression.
+func Dusk(absoluteDate float64, l Location, alpha float64) (Moment, bool) {
+	m, ok := sunDepression(absoluteDate, l, alpha, false)
+	if !ok {
+		return 0, false
+	}
+	return StandardFromUniversal(m, l), true
+}
+
+// sunriseDepression returns the depression of the center of the sun at
+// sunrise and sunset: refraction and semidiameter (50 arc minutes), plus the
+// dip of the horizon seen from the elevation of the location.
+func sunriseDepression(l Location) float64 {
+	const earthRadius = 6372000 // meters
+	dip := 0.0
+	if l.Elevation > 0 {
+		dip = degrees(math.Acos(earthRadius / (earthRadius + l.Elevation)))
+	}
+	return 50.0/60 + dip
+}
+
+// Sunrise returns the moment, in standard time, of sunrise on a given
+// absolute date at a location. It returns false if the sun does not rise.
+func Sunrise(absoluteDate float64, l Location) (Moment, bool) {
+	return Dawn(absoluteDate, l, sunriseDepression(l))
+}
+
+// Sunset returns the moment, in standard time, of sunset on a given absolute
+// date at a location. It returns false if the sun does not set.
+func Sunset(absoluteDate float64, l Location) (Moment, bool) {
+	return Dusk(absoluteDate, l, sunriseDepression(l))
+}
+
+// deltaT returns the difference between dynamical and universal time (in
+// days) in a given Gregorian year: a polynomial fit to the observed values
+// from 2005 to 2050, and the long-term parabola of Morrison and Stephenson
+// otherwise.
+func deltaT(year float64) float64 {
+	var seconds float64
+	if t := year - 2000; t >= 5 && t <= 50 {
+		seconds = 62.92 + 0.32217*t + 0.005589*t*t
+	} else {
+		u := (year - 1820) / 100
+		seconds = -20 + 32*u*u
+	}
+	return seconds / 86400
+}
+
+// meanSynodicMonth is the mean time (in days) from new moon to new moon.
+const meanSynodicMonth = 29.530588861
+
+// nthNewMoon returns the universal moment of the kth new moon after the one
+// of 6 January 2000.
+func nthNewMoon(k float64) Moment {
+	t := k / 1236.85 // Julian centuries since J2000
+	jde := 2451550.09766 + meanSynodicMonth*k + t*t*(0.00015437+t*(-0.000000150+t*0.00000000073))
+	e := 1 - t*(0.002516+t*0.0000074)
+	sun := radians(2.5534 + 29.10535670*k - t*t*(0.0000014+t*0.00000011))
+	moon := radians(201.5643 + 385.81693528*k + t*t*(0.0107582+t*(0.00001238-t*0.000000058)))
+	f := radians(160.7108 + 390.67050284*k - t*t*(0.0016118+t*(0.00000227-t*0.000000011)))
+	omega := radians(124.7746 - 1.56375588*k + t*t*(0.0020672+t*0.00000215))
+	correction := -0.40720*math.Sin(moon) +
+		0.17241*e*math.Sin(sun) +
+		0.01608*math.Sin(2*moon) +
+		0.01039*math.Sin(2*f) +
+		0.00739*e*math.Sin(moon-sun) -
+		0.00514*e*math.Sin(moon+sun) +
+		0.00208*e*e*math.Sin(2*sun) -
+		0.00111*math.Sin(moon-2*f) -
+		0.00057*math.Sin(moon+2*f) +
+		0.00056*e*math.Sin(2*moon+sun) -
+		0.00042*math.Sin(3*moon) +
+		0.00042*e*math.Sin(sun+2*f) +
+		0.00038*e*math.Sin(sun-2*f) -
+		0.00024*e*math.Sin(2*moon-sun) -
+		0.00017*math.Sin(omega) -
+		0.00007*math.Sin(moon+2*sun) +
+		0.00004*math.Sin(2*moon-2*f) +
+		0.00004*math.Sin(3*sun) +
+		0.00003*math.Sin(moon+sun-2*f) +
+		0.00003*math.Sin(2*moon+2*f) -
+		0.00003*math.Sin(moon+sun+2*f) +
+		0.00003*math.Sin(moon-sun+2*f) -
+		0.00002*math.Sin(moon-sun-2*f) -
+		0.00002*math.Sin(3*moon+sun) +
+		0.00002*math.Sin(4*moon)
+	// planetary arguments
+	for _, p := range [][3]float64{
+		{299.77, 0.107408, 0.000325}, {251.88, 0.016321, 0.000165}, {251.83, 26.651886, 0.000164},
+		{349.42, 36.412478, 0.000126}, {84.66, 18.206239, 0.000110}, {141.74, 53.303771, 0.000062},
+		{207.14, 2.453732, 0.000060}, {154.84, 7.306860, 0.000056}, {34.52, 27.261239, 0.000047},
+		{207.19, 0.121824, 0.000042}, {291.34, 1.844379, 0.000040}, {161.72, 24.198154, 0.000037},
+		{239.56, 25.513099, 0.000035}, {331.55, 3.592518, 0.000023},
+	} {
+		argument := p[0] + p[1]*k
+		if p[2] == 0.000325 {
+			argument -= 0.009173 * t * t
+		}
+		correction += p[2] * math.Sin(radians(argument))
+	}
+	m := AbsoluteFromJulianDay(jde + correction)
+	return Moment(m - deltaT(GregorianFromAbsolute(math.Floor(m)).Year))
+}
+
+// NewMoonAtOrAfter returns the universal moment of the first new moon at or
+// after a given universal moment.
+func NewMoonAtOrAfter(m Moment) Moment {
+	k := math.Floor((JulianDayFromAbsolute(float64(m))-2451550.09766)/meanSynodicMonth) - 1
+	for nthNewMoon(k) < m {
+		k++
+	}
+	return nthNewMoon(k)
+}
@@ -0,0 +1,78 @@
+// Copyright (C) 2022  Alexander Staudt
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package libcalendar
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestSunriseSunset(t *testing.T) {
+	newYork, _ := time.LoadLocation("America/New_York")
+	jerusalem, _ := time.LoadLocation("Asia/Jerusalem")
+	london, _ := time.LoadLocation("Europe/London")
+	var tests = []struct {
+		name     string
+		location Location
+		date     GregorianDate
+		sunrise  string // standard time, "" if the sun does not rise
+		sunset   string
+	}{
+		{"New York", Location{40.7128, -74.0060, 10, 0, newYork}, GregorianDate{2022, 6, 17}, "05:24", "20:30"},
+		{"Jerusalem", Location{31.7683, 35.2137, 0, 0, jerusalem}, GregorianDate{2022, 6, 17}, "05:33", "19:47"},
+		{"London", Location{51.5074, -0.1278, 0, 0, london}, GregorianDate{2022, 12, 21}, "08:04", "15:53"},
+		{"Sydney", Location{-33.8688, 151.2093, 0, 11, nil}, GregorianDate{2022, 12, 21}, "05:41", "20:05"},
+		{"Tromsø", Location{69.6492, 18.9553, 0, 2, nil}, GregorianDate{2022, 6, 21}, "", ""},
+	}
+	clock := func(m Moment, ok bool) string {
+		if !ok {
+			return ""
+		}
+		minutes := math.Round(m.TimeOfDay() * 1440)
+		return time.Date(0, 1, 1, 0, int(minutes), 0, 0, time.UTC).Format("15:04")
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			date := AbsoluteFromGregorian(tt.date)
+			sunrise, sunset := clock(Sunrise(date, tt.location)), clock(Sunset(date, tt.location))
+			t.Logf("got %v-%v, want %v-%v", sunrise, sunset, tt.sunrise, tt.sunset)
+			if sunrise != tt.sunrise || sunset != tt.sunset {
+				t.Errorf("got %v-%v, want %v-%v", sunrise, sunset, tt.sunrise, tt.sunset)
+			}
+		})
+	}
+}
+
+func TestSolarNoon(t *testing.T) {
+	l := Location{Latitude: 51.5074, Longitude: -0.1278}
+	var tests = []struct {
+		date GregorianDate
+		want float64 // minutes after midnight UT
+	}{
+		{GregorianDate{2022, 2, 11}, 12*60 + 14.5}, // equation of time near its minimum
+		{GregorianDate{2022, 11, 3}, 11*60 + 44},   // and maximum
+	}
+	for _, tt := range tests {
+		t.Run(tt.date.String(), func(t *testing.T) {
+			ans := SolarNoon(AbsoluteFromGregorian(tt.date), l).TimeOfDay() * 1440
+			t.Logf("got %v, want %v", ans, tt.want)
+			if math.Abs(ans-tt.want) > 1 {
+				t.Errorf("got %v, want %v", ans, tt.want)
+			}
+		})
+	}
+}
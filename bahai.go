@@ -0,0 +1,155 @@
+// Copyright (C) 2022  Alexander Staudt
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// This file implements the Bahá'í (Badí') calendar of 19 months of 19 days
+// and the intercalary days of Ayyám-i-Há. Until 171 B.E. (2014) the year began
+// on 21 March of the Gregorian calendar; since 172 B.E. it begins on the day
+// of the vernal equinox in Tehran (see Reingold/Dershowitz 2018).
+
+package libcalendar
+
+import "math"
+
+// Bahá'í date type. Years are grouped into cycles (Váḥid) of 19 years and
+// major cycles (Kull-i-Shay') of 19 cycles.
+type BahaiDate struct {
+	Major float64 // major cycle, 1 for the years 1 to 361
+	Cycle float64 // cycle within the major cycle, 1 to 19
+	Year  float64 // year within the cycle, 1 to 19
+	Month float64 // 1 to 19, or 0 for Ayyám-i-Há
+	Day   float64
+}
+
+// Bahá'í month numbers
+const (
+	ayyamIHa = 0
+	baha     = 1
+	ala      = 19
+)
+
+// BahaiEpoch is the absolute date of 1 Bahá 1 B.E. (21 March 1844).
+const BahaiEpoch float64 = 673222
+
+// bahaiAstronomicalYear is the first year (2015) whose beginning is
+// determined by the vernal equinox in Tehran.
+const bahaiAstronomicalYear = 172
+
+// Tehran, the reference location of the Bahá'í calendar
+var tehran = Location{Latitude: 35.696111, Longitude: 51.423056, Elevation: 0, Zone: 3.5}
+
+// BahaiYear returns the number of years since the epoch (the Bahá'í Era, B.E.)
+// of its receiver.
+func (d BahaiDate) BahaiYear() float64 {
+	return 361*(d.Major-1) + 19*(d.Cycle-1) + d.Year
+}
+
+// bahaiFromYear returns the first day of a given year of the Bahá'í Era.
+func bahaiFromYear(year float64) BahaiDate {
+	return BahaiDate{
+		Major: 1 + math.Floor((year-1)/361),
+		Cycle: 1 + math.Floor(mod(year-1, 361)/19),
+		Year:  1 + mod(year-1, 19),
+		Month: baha,
+		Day:   1,
+	}
+}
+
+// westernBahaiNewYear returns the absolute date of Naw-Rúz in a given year of
+// the Bahá'í Era, as fixed on 21 March.
+func westernBahaiNewYear(year float64) float64 {
+	return AbsoluteFromGregorian(GregorianDate{year + 1843, 3, 21})
+}
+
+// bahaiNewYear returns the absolute date of Naw-Rúz in a given year of the
+// Bahá'í Era: from 172 B.E., the day at whose sunset in Tehran the sun has
+// passed the vernal equinox.
+func bahaiNewYear(year float64) float64 {
+	if year < bahaiAstronomicalYear {
+		return westernBahaiNewYear(year)
+	}
+	date := AbsoluteFromGregorian(GregorianDate{year + 1843, 3, 18})
+	for {
+		sunset, ok := Sunset(date, tehran)
+		if !ok {
+			sunset = StandardFromLocal(Moment(date+meanSunset), tehran)
+		}
+		if ApparentSolarLongitude(UniversalFromStandard(sunset, tehran)) <= 2 {
+			return date
+		}
+		date++
+	}
+}
+
+// absoluteFromBahai returns the absolute date of a Bahá'í date, with years
+// beginning as given by newYear.
+func absoluteFromBahai(d BahaiDate, newYear func(float64) float64) float64 {
+	year := d.BahaiYear()
+	switch d.Month {
+	case ayyamIHa:
+		return newYear(year) + 341 + d.Day
+	case ala:
+		return newYear(year+1) - 20 + d.Day
+	default:
+		return newYear(year) + 19*(d.Month-1) + d.Day - 1
+	}
+}
+
+// bahaiFromAbsolute returns the Bahá'í date of an absolute date, with years
+// beginning as given by newYear.
+func bahaiFromAbsolute(absoluteDate float64, newYear func(float64) float64) BahaiDate {
+	year := GregorianFromAbsolute(absoluteDate).Year - 1843
+	if absoluteDate < newYear(year) {
+		year--
+	}
+	d := bahaiFromYear(year)
+	start := newYear(year)
+	switch {
+	case absoluteDate >= newYear(year+1)-19:
+		d.Month = ala
+		d.Day = absoluteDate - newYear(year+1) + 20
+	case absoluteDate >= start+342:
+		d.Month = ayyamIHa
+		d.Day = absoluteDate - start - 341
+	default:
+		d.Month = 1 + math.Floor((absoluteDate-start)/19)
+		d.Day = 1 + mod(absoluteDate-start, 19)
+	}
+	return d
+}
+
+// AbsoluteFromBahai computes the absolute (fixed) date from a given Bahá'í
+// date.
+func AbsoluteFromBahai(d BahaiDate) (absoluteDate float64) {
+	return absoluteFromBahai(d, bahaiNewYear)
+}
+
+// BahaiFromAbsolute computes the Bahá'í date from a given absolute (fixed)
+// date.
+func BahaiFromAbsolute(absoluteDate float64) BahaiDate {
+	return bahaiFromAbsolute(absoluteDate, bahaiNewYear)
+}
+
+// AbsoluteFromWesternBahai computes the absolute (fixed) date from a given
+// Bahá'í date, using the arithmetic rule in use in the West until 2014, by
+// which every year begins on 21 March.
+func AbsoluteFromWesternBahai(d BahaiDate) (absoluteDate float64) {
+	return absoluteFromBahai(d, westernBahaiNewYear)
+}
+
+// WesternBahaiFromAbsolute computes the Bahá'í date from a given absolute
+// (fixed) date, using the arithmetic rule in use in the West until 2014.
+func WesternBahaiFromAbsolute(absoluteDate float64) BahaiDate {
+	return bahaiFromAbsolute(absoluteDate, westernBahaiNewYear)
+}
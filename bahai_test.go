@@ -0,0 +1,112 @@
+// Copyright (C) 2022  Alexander Staudt
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package libcalendar
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestBahaiFromAbsolute(t *testing.T) {
+	var tests = []struct {
+		rd      float64
+		want    BahaiDate
+		western BahaiDate
+		name    string
+	}{
+		{-214193, BahaiDate{-6, 6, 3, 7, 12}, BahaiDate{-6, 6, 3, 7, 12}, "12 Kalimát -2429"},
+		{BahaiEpoch, BahaiDate{1, 1, 1, 1, 1}, BahaiDate{1, 1, 1, 1, 1}, "1 Bahá 1"},
+		{AbsoluteFromGregorian(GregorianDate{2015, 3, 21}), BahaiDate{1, 10, 1, 1, 1}, BahaiDate{1, 10, 1, 1, 1}, "1 Bahá 172"},
+		{AbsoluteFromGregorian(GregorianDate{2016, 3, 20}), BahaiDate{1, 10, 2, 1, 1}, BahaiDate{1, 10, 1, 19, 19}, "1 Bahá 173"},
+		{AbsoluteFromGregorian(GregorianDate{2024, 2, 26}), BahaiDate{1, 10, 9, 0, 1}, BahaiDate{1, 10, 9, 0, 1}, "1 Ayyám-i-Há 180"},
+		{AbsoluteFromGregorian(GregorianDate{2025, 3, 1}), BahaiDate{1, 10, 10, 19, 1}, BahaiDate{1, 10, 10, 0, 4}, "1 ʻAláʼ 181"},
+	}
+	for _, tt := range tests {
+		testname := fmt.Sprintf("%.0f", tt.rd)
+		t.Run(testname, func(t *testing.T) {
+			got := BahaiFromAbsolute(tt.rd)
+			t.Logf("got %v (%v), want %v (%v)", got, got.String(), tt.want, tt.name)
+			if got != tt.want || got.String() != tt.name {
+				t.Errorf("got %v (%v), want %v (%v)", got, got.String(), tt.want, tt.name)
+			}
+			if d := AbsoluteFromBahai(got); d != tt.rd {
+				t.Errorf("got %v, want %v", d, tt.rd)
+			}
+			if w := WesternBahaiFromAbsolute(tt.rd); w != tt.western {
+				t.Errorf("got %v, want %v", w, tt.western)
+			}
+			if d := AbsoluteFromWesternBahai(tt.western); d != tt.rd {
+				t.Errorf("got %v, want %v", d, tt.rd)
+			}
+			if err := ValidateDate(got.Date()); err != nil {
+				t.Errorf("got %v, want %v", err, nil)
+			}
+		})
+	}
+
+	t.Run("round trip", func(t *testing.T) {
+		from := AbsoluteFromGregorian(GregorianDate{2000, 1, 1})
+		for rd := from; rd < from+365*40; rd++ {
+			if d := AbsoluteFromBahai(BahaiFromAbsolute(rd)); d != rd {
+				t.Fatalf("got %v, want %v", d, rd)
+			}
+			if d := AbsoluteFromWesternBahai(WesternBahaiFromAbsolute(rd)); d != rd {
+				t.Fatalf("got %v, want %v", d, rd)
+			}
+		}
+	})
+
+	t.Run("components", func(t *testing.T) {
+		d := DateFromComponents("bahai", []float64{182, 1, 1})
+		want := AbsoluteFromGregorian(GregorianDate{2025, 3, 20})
+		if got := AbsoluteFromDate(d); got != want || d.Weekday != "Istijlál" {
+			t.Errorf("got %v %v, want %v %v", got, d.Weekday, want, "Istijlál")
+		}
+	})
+}
+
+func TestBahaiHolidays(t *testing.T) {
+	var tests = []struct {
+		year   float64
+		nawRuz GregorianDate
+		bab    GregorianDate
+	}{
+		{2014, GregorianDate{2014, 3, 21}, GregorianDate{2014, 10, 20}},
+		{2015, GregorianDate{2015, 3, 21}, GregorianDate{2015, 11, 13}},
+		{2016, GregorianDate{2016, 3, 20}, GregorianDate{2016, 11, 1}},
+		{2017, GregorianDate{2017, 3, 20}, GregorianDate{2017, 10, 21}},
+		{2018, GregorianDate{2018, 3, 21}, GregorianDate{2018, 11, 9}},
+		{2020, GregorianDate{2020, 3, 20}, GregorianDate{2020, 10, 18}},
+		{2022, GregorianDate{2022, 3, 21}, GregorianDate{2022, 10, 26}},
+		{2023, GregorianDate{2023, 3, 21}, GregorianDate{2023, 10, 16}},
+		{2024, GregorianDate{2024, 3, 20}, GregorianDate{2024, 11, 2}},
+		{2025, GregorianDate{2025, 3, 20}, GregorianDate{2025, 10, 22}},
+	}
+	for _, tt := range tests {
+		testname := fmt.Sprintf("%.0f", tt.year)
+		t.Run(testname, func(t *testing.T) {
+			nawRuz := GregorianFromAbsolute(NawRuz(tt.year))
+			bab := GregorianFromAbsolute(BirthOfTheBab(tt.year))
+			t.Logf("got %v %v, want %v %v", nawRuz, bab, tt.nawRuz, tt.bab)
+			if nawRuz != tt.nawRuz || bab != tt.bab {
+				t.Errorf("got %v %v, want %v %v", nawRuz, bab, tt.nawRuz, tt.bab)
+			}
+			if tt.year >= 2015 && BirthOfBahaullah(tt.year) != BirthOfTheBab(tt.year)+1 {
+				t.Errorf("got %v, want %v", BirthOfBahaullah(tt.year), BirthOfTheBab(tt.year)+1)
+			}
+		})
+	}
+}
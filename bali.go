@@ -0,0 +1,125 @@
+// Copyright (C) 2022  Alexander Staudt
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// This file implements the Balinese Pawukon calendar, a cycle of 210 days
+// counted simultaneously in ten weeks of one to ten days (see
+// Reingold/Dershowitz 2018).
+
+package libcalendar
+
+import "math"
+
+// Balinese Pawukon date type. Each component is the position of the day in
+// one of the ten concurrent weeks, named after the number of their days.
+type BaliPawukonDate struct {
+	Luang     bool    // 1-day week
+	Dwiwara   float64 // 2-day week, 1 to 2
+	Triwara   float64 // 3-day week, 1 to 3
+	Caturwara float64 // 4-day week, 1 to 4
+	Pancawara float64 // 5-day week, 1 to 5
+	Sadwara   float64 // 6-day week, 1 to 6
+	Saptawara float64 // 7-day week, 1 (Redite, Sunday) to 7
+	Asatawara float64 // 8-day week, 1 to 8
+	Sangawara float64 // 9-day week, 1 to 9
+	Dasawara  float64 // 10-day week, 0 to 9
+}
+
+// BaliEpoch is the absolute date of the beginning of a Pawukon cycle,
+// Julian day 146.
+const BaliEpoch float64 = -1721279
+
+// baliDayFromAbsolute returns the position (0 to 209) of a given absolute
+// date in the Pawukon cycle.
+func baliDayFromAbsolute(absoluteDate float64) float64 {
+	return mod(absoluteDate-BaliEpoch, 210)
+}
+
+// baliPawukonFromDay returns the Pawukon date of a given position in the
+// cycle. The 4-, 8- and 9-day weeks repeat a day to fit the cycle, and the
+// 10-day (and thus the 1- and 2-day) week is derived from the 5- and 7-day
+// weeks.
+func baliPawukonFromDay(day float64) BaliPawukonDate {
+	pancawara := amod(day+2, 5)
+	saptawara := mod(day, 7) + 1
+	asatawara := mod(math.Max(6, 4+mod(day-70, 210)), 8) + 1
+	dasawara := mod(1+[]float64{5, 9, 7, 4, 8}[int(pancawara-1)]+[]float64{5, 4, 3, 7, 8, 6, 9}[int(saptawara-1)], 10)
+	return BaliPawukonDate{
+		Luang:     mod(dasawara, 2) == 0,
+		Dwiwara:   amod(dasawara, 2),
+		Triwara:   mod(day, 3) + 1,
+		Caturwara: amod(asatawara, 4),
+		Pancawara: pancawara,
+		Sadwara:   mod(day, 6) + 1,
+		Saptawara: saptawara,
+		Asatawara: asatawara,
+		Sangawara: mod(math.Max(0, day-3), 9) + 1,
+		Dasawara:  dasawara,
+	}
+}
+
+// day returns the position (0 to 209) of its receiver in the Pawukon cycle,
+// as determined by its 5-, 6- and 7-day weeks.
+func (d BaliPawukonDate) day() float64 {
+	a5, a6, b7 := d.Pancawara-1, d.Sadwara-1, d.Saptawara-1
+	b35 := mod(a5+14+15*(b7-a5), 35)
+	return mod(a6+36*(b35-a6), 210)
+}
+
+// Week returns the number (1 to 30) of the 7-day week (wuku) of its receiver.
+func (d BaliPawukonDate) Week() float64 {
+	return math.Floor(d.day()/7) + 1
+}
+
+// BaliPawukonFromAbsolute returns the Balinese Pawukon date corresponding to
+// a given absolute (fixed) date.
+func BaliPawukonFromAbsolute(absoluteDate float64) BaliPawukonDate {
+	return baliPawukonFromDay(baliDayFromAbsolute(absoluteDate))
+}
+
+// BaliPawukonOnOrBefore returns the absolute (fixed) date of the latest day
+// on or before a given absolute date with the 5-, 6- and 7-day weeks of a
+// given Pawukon date.
+func BaliPawukonOnOrBefore(pawukon BaliPawukonDate, d float64) (absoluteDate float64) {
+	return d - mod(d-BaliEpoch-pawukon.day(), 210)
+}
+
+// baliPositionsInRange returns the absolute dates from absolute date `from`
+// through absolute date `to` at position p of a c-day cycle of the Pawukon.
+func baliPositionsInRange(p, c, from, to float64) (absoluteDates []float64) {
+	absoluteDates = []float64{}
+	for d := from + mod(p-baliDayFromAbsolute(from), c); d <= to; d += c {
+		absoluteDates = append(absoluteDates, d)
+	}
+	return absoluteDates
+}
+
+// KajengKeliwon returns a slice of absolute (fixed) dates of Kajeng Keliwon,
+// the days that are both Kajeng (the third day of the 3-day week) and
+// Keliwon (the fifth day of the 5-day week), in a given Gregorian year.
+func KajengKeliwon(year float64) (absoluteDates []float64) {
+	return baliPositionsInRange(8, 15,
+		AbsoluteFromGregorian(GregorianDate{year, january, 1}),
+		AbsoluteFromGregorian(GregorianDate{year, december, 31}))
+}
+
+// Tumpek returns a slice of absolute (fixed) dates of Tumpek, the days that
+// are both Saniscara (Saturday) and Keliwon, in a given Gregorian year. The
+// six Tumpek of a Pawukon cycle are named after their week, e.g. Tumpek
+// Landep on Saniscara Keliwon Landep.
+func Tumpek(year float64) (absoluteDates []float64) {
+	return baliPositionsInRange(13, 35,
+		AbsoluteFromGregorian(GregorianDate{year, january, 1}),
+		AbsoluteFromGregorian(GregorianDate{year, december, 31}))
+}
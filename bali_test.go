@@ -0,0 +1,84 @@
+// Copyright (C) 2022  Alexander Staudt
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package libcalendar
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestBaliPawukonFromAbsolute(t *testing.T) {
+	var tests = []struct {
+		rd   float64
+		want BaliPawukonDate
+		name string
+	}{
+		{-214193, BaliPawukonDate{false, 1, 1, 1, 3, 1, 1, 5, 7, 3}, "Redite Pon Tambir"},
+		{AbsoluteFromGregorian(GregorianDate{2022, 6, 8}), BaliPawukonDate{true, 2, 2, 4, 5, 2, 4, 8, 8, 6}, "Buda Keliwon Dunggulan"}, // Galungan
+		{AbsoluteFromGregorian(GregorianDate{2024, 9, 25}), BaliPawukonDate{true, 2, 2, 4, 5, 2, 4, 8, 8, 6}, "Buda Keliwon Dunggulan"},
+		{AbsoluteFromGregorian(GregorianDate{2022, 6, 18}), BaliPawukonDate{true, 2, 3, 2, 5, 6, 7, 2, 9, 8}, "Saniscara Keliwon Kuningan"}, // Kuningan
+	}
+	for _, tt := range tests {
+		testname := fmt.Sprintf("%.0f", tt.rd)
+		t.Run(testname, func(t *testing.T) {
+			got := BaliPawukonFromAbsolute(tt.rd)
+			t.Logf("got %v %v, want %v %v", got.Names(), got, tt.want, tt.name)
+			if got != tt.want || got.String() != tt.name {
+				t.Errorf("got %v %v, want %v %v", got.Names(), got, tt.want, tt.name)
+			}
+			if d := BaliPawukonOnOrBefore(got, tt.rd+209); d != tt.rd {
+				t.Errorf("got %v, want %v", d, tt.rd)
+			}
+			if err := ValidateDate(got.Date()); err != nil {
+				t.Errorf("got %v, want %v", err, nil)
+			}
+		})
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		d := DateFromComponents("baliPawukon", []float64{0, 1, 1, 1, 3, 1, 1, 5, 7, 4})
+		if err := ValidateDate(d); err != ErrInvalidDate {
+			t.Errorf("got %v, want %v", err, ErrInvalidDate)
+		}
+	})
+}
+
+func TestBaliHolidays(t *testing.T) {
+	var tests = []struct {
+		name  string
+		dates []float64
+		first GregorianDate
+		count int
+	}{
+		{"Kajeng Keliwon", KajengKeliwon(2022), GregorianDate{2022, 1, 4}, 25},
+		{"Tumpek", Tumpek(2022), GregorianDate{2022, 1, 29}, 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			first := GregorianFromAbsolute(tt.dates[0])
+			t.Logf("got %v (%v), want %v (%v)", first, len(tt.dates), tt.first, tt.count)
+			if first != tt.first || len(tt.dates) != tt.count {
+				t.Errorf("got %v (%v), want %v (%v)", first, len(tt.dates), tt.first, tt.count)
+			}
+			for _, d := range tt.dates {
+				b := BaliPawukonFromAbsolute(d)
+				if b.Pancawara != 5 || (tt.name == "Tumpek" && b.Saptawara != 7) || (tt.name != "Tumpek" && b.Triwara != 3) {
+					t.Errorf("got %v on %v", b.Names(), GregorianFromAbsolute(d))
+				}
+			}
+		})
+	}
+}
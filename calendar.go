@@ -15,8 +15,10 @@
 
 // Package libcalendar implements functions to compute and convert dates
 // from various calendars. These are the
-// Gregorian, ISO, Julian, Islamic, Hebrew, Mayan (long count, haab, tzolkin),
-// French Revolutionary, and Old Hindu (solar, lunar) calendars.
+// Gregorian, ISO, Julian (also in Roman nomenclature), Islamic, Hebrew, Mayan
+// (long count, haab, tzolkin), Aztec (xihuitl, tonalpohualli), Balinese
+// Pawukon, French Revolutionary, Egyptian, Armenian, Old Hindu (solar,
+// lunar), modern Hindu (solar, lunisolar), and Bahá'í calendars.
 //
 // The calendrical algorithms are a translation of the Lisp code discussed in:
 //
@@ -31,6 +33,7 @@
 package libcalendar // import "staudtlex.de/libcalendar"
 
 import (
+	"fmt"
 	"math"
 	"math/big"
 )
@@ -509,12 +512,9 @@ type MayanTzolkinDate struct {
 
 // Number of days of the Mayan calendar epoch before absolute day 0, according
 // to the Goodman-Martinez-Thompson correlation (see Reingold/Dershowitz 2018).
+// For other correlations, see MayanCalendar.
 const MayanDaysBeforeAbsoluteZero float64 = 1137142
 
-// Number of days of the Mayan calendar epoch before absolute day 0, according
-// to the Spinden correlation.
-//const MayanDaysBeforeAbsoluteZero float64 = 1232041
-
 // AbsoluteFromMayanLongCount returns the absolute (fixed) date of a given
 // Mayan long count.
 func AbsoluteFromMayanLongCount(d MayanLongCount) (date float64) {
@@ -555,14 +555,18 @@ func MayanHaabFromAbsolute(absoluteDate float64) MayanHaabDate {
 }
 
 // MayanHaabDifference computes the number of days between two haab dates.
+// Earlier versions weighted the difference of days by 20 rather than that of
+// months, and returned wrong differences for haab dates in different months.
 func MayanHaabDifference(d1, d2 MayanHaabDate) (days float64) {
-	return mod(20*(d2.Day-d1.Day)+(d2.Month-d1.Month), 365)
+	return mod(20*(d2.Month-d1.Month)+(d2.Day-d1.Day), 365)
 }
 
 // MayanHaabOnOrBefore returns the absolute (fixed) date of a Mayan haab date
-// on or before a given absolute date.
+// on or before a given absolute date. Earlier versions subtracted from the
+// given date the distance of the haab date before absolute date 0, rather
+// than before the given date.
 func MayanHaabOnOrBefore(haab MayanHaabDate, d float64) (date float64) {
-	return d - mod(date-MayanHaabDifference(MayanHaabFromAbsolute(0), haab), 365)
+	return d - mod(d-MayanHaabDifference(MayanHaabFromAbsolute(0), haab), 365)
 }
 
 // MayanTzolkinAtEpoch denotes tha tzolkin date at long count 0.0.0.0.0.
@@ -587,16 +591,168 @@ func MayanTzolkinDifference(d1, d2 MayanTzolkinDate) (days float64) {
 
 // MayanHaabTzolkinOnOrBefore returns the absolute date of the latest date on
 // or before a given haab date and a given tzolkin date. Returns NaN when no
-// such combination is found.
+// such combination is found; see MayanCalendarRoundOnOrBefore for a variant
+// reporting an error instead. Results differ from those of earlier versions
+// only where these used the wrong haab differences of MayanHaabDifference.
 func MayanHaabTzolkinOnOrBefore(haab MayanHaabDate, tzolkin MayanTzolkinDate, d float64) (absoluteDate float64) {
-	haabDifference := MayanHaabDifference(MayanHaabFromAbsolute(0), haab)
-	tzolkinDifference := MayanTzolkinDifference(MayanTzolkinFromAbsolute(0), tzolkin)
-	difference := tzolkinDifference - haabDifference
-	if mod(difference, 5) == 0 {
-		return d - mod(d-(haabDifference+(365*difference)), 18980)
-	} else {
+	absoluteDate, err := MayanCalendarRoundOnOrBefore(haab, tzolkin, d)
+	if err != nil {
 		return math.NaN()
 	}
+	return absoluteDate
+}
+
+// The Aztec Calendars
+
+// Aztec xihuitl months
+const (
+	izcalli            = 1
+	atlcahualo         = 2
+	tlacaxipehualiztli = 3
+	tozoztontli        = 4
+	hueiTozoztli       = 5
+	toxcatl            = 6
+	etzalcualiztli     = 7
+	tecuilhuitontli    = 8
+	hueiTecuilhuitl    = 9
+	tlaxochimaco       = 10
+	xocotlhuetzi       = 11
+	ochpaniztli        = 12
+	teotleco           = 13
+	tepeilhuitl        = 14
+	quecholli          = 15
+	panquetzaliztli    = 16
+	atemoztli          = 17
+	tititl             = 18
+	nemontemi          = 19 // five days
+)
+
+// Aztec tonalpohualli day signs
+const (
+	cipactli      = 1
+	ehecatl       = 2
+	calli         = 3
+	cuetzpallin   = 4
+	coatl         = 5
+	miquiztli     = 6
+	mazatl        = 7
+	tochtli       = 8
+	atl           = 9
+	itzcuintli    = 10
+	ozomatli      = 11
+	malinalli     = 12
+	acatl         = 13
+	ocelotl       = 14
+	quauhtli      = 15
+	cozcaquauhtli = 16
+	ollin         = 17
+	tecpatl       = 18
+	quiahuitl     = 19
+	xochitl       = 20
+)
+
+// Aztec Xihuitl date type
+type AztecXihuitlDate struct {
+	Day   float64 // 1 to 20, 1 to 5 in Nemontemi
+	Month float64
+}
+
+// Aztec Tonalpohualli date type
+type AztecTonalpohualliDate struct {
+	Number float64
+	Name   float64 // day sign
+}
+
+// AztecCorrelation is the absolute date of 13 August 1521 (Julian), the day
+// of the fall of Tenochtitlan, dated 2 Xocotlhuetzi 1 Coatl (see
+// Reingold/Dershowitz 2018).
+const AztecCorrelation float64 = 555403
+
+// Absolute dates of 1 Izcalli and of 1 Cipactli preceding AztecCorrelation
+const (
+	aztecXihuitlCorrelation       = AztecCorrelation - 201 // 2 Xocotlhuetzi is day 201 of the xihuitl
+	aztecTonalpohualliCorrelation = AztecCorrelation - 104 // 1 Coatl is day 104 of the tonalpohualli
+)
+
+// aztecXihuitlOrdinal returns the number of days (0 to 364) from 1 Izcalli
+// to a given xihuitl date.
+func aztecXihuitlOrdinal(d AztecXihuitlDate) float64 {
+	return (d.Month-1)*20 + d.Day - 1
+}
+
+// aztecTonalpohualliOrdinal returns the number of days (0 to 259) from
+// 1 Cipactli to a given tonalpohualli date.
+func aztecTonalpohualliOrdinal(d AztecTonalpohualliDate) float64 {
+	return mod(d.Number-1+39*(d.Number-d.Name), 260)
+}
+
+// AztecXihuitlFromAbsolute returns the Aztec xihuitl date corresponding to a
+// given absolute (fixed) date.
+func AztecXihuitlFromAbsolute(absoluteDate float64) AztecXihuitlDate {
+	count := mod(absoluteDate-aztecXihuitlCorrelation, 365)
+	return AztecXihuitlDate{mod(count, 20) + 1, math.Floor(count/20) + 1}
+}
+
+// AztecXihuitlOnOrBefore returns the absolute (fixed) date of an Aztec xihuitl
+// date on or before a given absolute date.
+func AztecXihuitlOnOrBefore(xihuitl AztecXihuitlDate, d float64) (absoluteDate float64) {
+	return d - mod(d-aztecXihuitlCorrelation-aztecXihuitlOrdinal(xihuitl), 365)
+}
+
+// AztecTonalpohualliFromAbsolute returns the Aztec tonalpohualli date
+// corresponding to a given absolute (fixed) date.
+func AztecTonalpohualliFromAbsolute(absoluteDate float64) AztecTonalpohualliDate {
+	count := absoluteDate - aztecTonalpohualliCorrelation + 1
+	return AztecTonalpohualliDate{amod(count, 13), amod(count, 20)}
+}
+
+// AztecTonalpohualliOnOrBefore returns the absolute (fixed) date of an Aztec
+// tonalpohualli date on or before a given absolute date.
+func AztecTonalpohualliOnOrBefore(tonalpohualli AztecTonalpohualliDate, d float64) (absoluteDate float64) {
+	return d - mod(d-aztecTonalpohualliCorrelation-aztecTonalpohualliOrdinal(tonalpohualli), 260)
+}
+
+// validAztecRound checks the components of a xihuitl and a tonalpohualli
+// date.
+func validAztecRound(xihuitl AztecXihuitlDate, tonalpohualli AztecTonalpohualliDate) bool {
+	for _, x := range []float64{xihuitl.Day, xihuitl.Month, tonalpohualli.Number, tonalpohualli.Name} {
+		if x != math.Floor(x) {
+			return false
+		}
+	}
+	return xihuitl.Day >= 1 && xihuitl.Day <= 20 && xihuitl.Month >= 1 && xihuitl.Month <= 19 &&
+		!(xihuitl.Month == nemontemi && xihuitl.Day > 5) &&
+		tonalpohualli.Number >= 1 && tonalpohualli.Number <= 13 && tonalpohualli.Name >= 1 && tonalpohualli.Name <= 20
+}
+
+// AztecXihuitlTonalpohualliOnOrBefore returns the latest absolute date on or
+// before d with a given xihuitl and tonalpohualli date. As with the Mayan
+// Calendar Round, only a quarter of all combinations occur; for the others,
+// it returns ErrImpossibleCalendarRound. Components out of range return
+// ErrInvalidDate.
+func AztecXihuitlTonalpohualliOnOrBefore(xihuitl AztecXihuitlDate, tonalpohualli AztecTonalpohualliDate, d float64) (absoluteDate float64, err error) {
+	if !validAztecRound(xihuitl, tonalpohualli) {
+		return math.NaN(), fmt.Errorf("%w: %v %v", ErrInvalidDate, tonalpohualli, xihuitl)
+	}
+	xihuitlCount := aztecXihuitlOrdinal(xihuitl) + aztecXihuitlCorrelation
+	tonalpohualliCount := aztecTonalpohualliOrdinal(tonalpohualli) + aztecTonalpohualliCorrelation
+	difference := tonalpohualliCount - xihuitlCount
+	if mod(difference, 5) != 0 {
+		return math.NaN(), fmt.Errorf("%w: %v %v", ErrImpossibleCalendarRound, tonalpohualli, xihuitl)
+	}
+	return d - mod(d-(xihuitlCount+365*difference), 18980), nil
+}
+
+// AztecXiuhmolpilliFromAbsolute returns the name of the year (its year
+// bearer) containing a given absolute date, in the 52-year cycle
+// (xiuhmolpilli): the tonalpohualli date of the last day of Tititl, e.g.
+// 3 Calli. The days of Nemontemi belong to no year, for them ok is false.
+func AztecXiuhmolpilliFromAbsolute(absoluteDate float64) (yearBearer AztecTonalpohualliDate, ok bool) {
+	if AztecXihuitlFromAbsolute(absoluteDate).Month == nemontemi {
+		return AztecTonalpohualliDate{}, false
+	}
+	last := AztecXihuitlOnOrBefore(AztecXihuitlDate{20, tititl}, absoluteDate+364)
+	return AztecTonalpohualliFromAbsolute(last), true
 }
 
 // The French Revolutionary Calendar
@@ -759,7 +915,7 @@ func Zodiac(t *big.Rat) (zodiac float64) {
 // OldHinduSolarFromAbsolute computes the Old Hindu solar date corresponding
 // to a given absolute (fixed) date.
 func OldHinduSolarFromAbsolute(absoluteDate float64) OldHinduSolarDate {
-	hdate := add(big.NewRat(int64(absoluteDate), 1), big.NewRat(1132959, 1), big.NewRat(1, 4))
+	hdate := oldHinduDays(OldHinduSunrise(absoluteDate))
 	year := quotient(hdate, SolarSiderealYear)
 	month := Zodiac(hdate)
 	day := floorf(modr(hdate, SolarMonth)) + 1
@@ -807,8 +963,7 @@ func NewMoon(t *big.Rat) *big.Rat {
 // OldHinduLunarFromAbsolute returns the Old Hindu lunar date corresponding to
 // a given absolute (fixed) date.
 func OldHinduLunarFromAbsolute(absoluteDate float64) OldHinduLunarDate {
-	hdate := big.NewRat(int64(absoluteDate+1132959), 1)
-	sunrise := add(hdate, big.NewRat(1, 4))
+	sunrise := oldHinduDays(OldHinduSunrise(absoluteDate))
 	lastNewMoon := NewMoon(sunrise)
 	nextNewMoon := add(lastNewMoon, LunarSynodicMonth)
 	month := amod(Zodiac(lastNewMoon)+1, 12)
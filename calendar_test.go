@@ -16,7 +16,9 @@
 package libcalendar
 
 import (
+	"errors"
 	"fmt"
+	"math"
 	"testing"
 )
 
@@ -339,6 +341,77 @@ func TestMayanTzolkinFromAbsolute(t *testing.T) {
 	}
 }
 
+// Aztec calendars
+func TestAztecFromAbsolute(t *testing.T) {
+	var tests = []struct {
+		rd            float64
+		xihuitl       AztecXihuitlDate
+		tonalpohualli AztecTonalpohualliDate
+		yearBearer    AztecTonalpohualliDate
+	}{
+		{-214193, AztecXihuitlDate{6, atlcahualo}, AztecTonalpohualliDate{5, atl}, AztecTonalpohualliDate{1, calli}},
+		{AztecCorrelation, AztecXihuitlDate{2, xocotlhuetzi}, AztecTonalpohualliDate{1, coatl}, AztecTonalpohualliDate{3, calli}},
+		{738321, AztecXihuitlDate{15, teotleco}, AztecTonalpohualliDate{9, calli}, AztecTonalpohualliDate{10, tochtli}},
+	}
+	for _, tt := range tests {
+		testname := fmt.Sprintf("%.0f", tt.rd)
+		t.Run(testname, func(t *testing.T) {
+			xihuitl, tonalpohualli := AztecXihuitlFromAbsolute(tt.rd), AztecTonalpohualliFromAbsolute(tt.rd)
+			yearBearer, ok := AztecXiuhmolpilliFromAbsolute(tt.rd)
+			t.Logf("got %v %v (%v), want %v %v (%v)", tonalpohualli, xihuitl, yearBearer, tt.tonalpohualli, tt.xihuitl, tt.yearBearer)
+			if xihuitl != tt.xihuitl || tonalpohualli != tt.tonalpohualli || yearBearer != tt.yearBearer || !ok {
+				t.Errorf("got %v %v (%v), want %v %v (%v)", tonalpohualli, xihuitl, yearBearer, tt.tonalpohualli, tt.xihuitl, tt.yearBearer)
+			}
+			if d := AztecXihuitlOnOrBefore(xihuitl, tt.rd+364); d != tt.rd {
+				t.Errorf("got %v, want %v", d, tt.rd)
+			}
+			if d := AztecTonalpohualliOnOrBefore(tonalpohualli, tt.rd+259); d != tt.rd {
+				t.Errorf("got %v, want %v", d, tt.rd)
+			}
+			if d, err := AztecXihuitlTonalpohualliOnOrBefore(xihuitl, tonalpohualli, tt.rd+18979); d != tt.rd || err != nil {
+				t.Errorf("got %v (%v), want %v", d, err, tt.rd)
+			}
+		})
+	}
+
+	t.Run("nemontemi", func(t *testing.T) {
+		rd := AztecXihuitlOnOrBefore(AztecXihuitlDate{1, nemontemi}, 738321)
+		_, ok := AztecXiuhmolpilliFromAbsolute(rd)
+		if ok {
+			t.Errorf("got %v, want %v", ok, false)
+		}
+	})
+
+	t.Run("impossible", func(t *testing.T) {
+		_, err := AztecXihuitlTonalpohualliOnOrBefore(AztecXihuitlDate{3, xocotlhuetzi}, AztecTonalpohualliDate{1, coatl}, 738321)
+		if !errors.Is(err, ErrImpossibleCalendarRound) {
+			t.Errorf("got %v, want %v", err, ErrImpossibleCalendarRound)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		var tests = []struct {
+			xihuitl       AztecXihuitlDate
+			tonalpohualli AztecTonalpohualliDate
+		}{
+			{AztecXihuitlDate{0, xocotlhuetzi}, AztecTonalpohualliDate{1, coatl}},
+			{AztecXihuitlDate{21, xocotlhuetzi}, AztecTonalpohualliDate{1, coatl}},
+			{AztecXihuitlDate{6, nemontemi}, AztecTonalpohualliDate{1, coatl}},
+			{AztecXihuitlDate{2, 20}, AztecTonalpohualliDate{1, coatl}},
+			{AztecXihuitlDate{2.5, xocotlhuetzi}, AztecTonalpohualliDate{1, coatl}},
+			{AztecXihuitlDate{2, xocotlhuetzi}, AztecTonalpohualliDate{14, coatl}},
+			{AztecXihuitlDate{2, xocotlhuetzi}, AztecTonalpohualliDate{1, 21}},
+			{AztecXihuitlDate{2, xocotlhuetzi}, AztecTonalpohualliDate{1, 0.5}},
+		}
+		for _, tt := range tests {
+			d, err := AztecXihuitlTonalpohualliOnOrBefore(tt.xihuitl, tt.tonalpohualli, 738321)
+			if !errors.Is(err, ErrInvalidDate) || !math.IsNaN(d) {
+				t.Errorf("got %v (%v), want NaN (%v)", d, err, ErrInvalidDate)
+			}
+		}
+	})
+}
+
 // French Revolutionary calendar
 func TestAbsoluteFromFrench(t *testing.T) {
 	tests := make([]struct {
@@ -0,0 +1,52 @@
+// Copyright (C) 2022  Alexander Staudt
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Command calendard serves libcalendar conversions, holidays and date
+// validation over HTTP, using the Date JSON format of libcalendar.
+//
+// Usage:
+//
+//	calendard [-addr host:port]
+//
+// The API is described by the OpenAPI document served at /openapi.json.
+// Examples:
+//
+//	curl 'localhost:8080/convert?calendar=gregorian&components=2022,6,15&to=hebrew'
+//	curl -d '{"calendar":"hebrew","components":[5782,9,25]}' localhost:8080/convert
+//	curl 'localhost:8080/holidays?year=2022'
+//	curl 'localhost:8080/holidays?from=2022-6-1&to=2022-6-30'
+//	curl -d '{"calendar":"gregorian","components":[2022,2,29]}' localhost:8080/validate
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"time"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "listen on `address`")
+	flag.Parse()
+
+	server := &http.Server{
+		Addr:         *addr,
+		Handler:      newHandler(),
+		ReadTimeout:  10 * time.Second,
+		WriteTimeout: 10 * time.Second,
+	}
+	log.Printf("calendard listening on %s", *addr)
+	log.Fatal(server.ListenAndServe())
+}
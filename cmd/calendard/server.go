@@ -0,0 +1,289 @@
+// Copyright (C) 2022  Alexander Staudt
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	lc "staudtlex.de/libcalendar"
+)
+
+// openAPI is the OpenAPI description of the server's endpoints.
+//
+//go:embed openapi.json
+var openAPI []byte
+
+// maxBodySize limits the size of request bodies.
+const maxBodySize = 1 << 16
+
+// minYear and maxYear limit the Gregorian years of the dates served, i.e.
+// of holidays and of the absolute dates of conversions.
+const (
+	minYear = -9999
+	maxYear = 9999
+)
+
+// errOutOfRange is returned for dates outside the years served.
+var errOutOfRange = fmt.Errorf("date out of range (Gregorian years %d to %d)", minYear, maxYear)
+
+// inRange reports whether an absolute date lies within the years served.
+func inRange(absoluteDate float64) bool {
+	return absoluteDate >= lc.AbsoluteFromGregorian(lc.GregorianDate{Year: minYear, Month: 1, Day: 1}) &&
+		absoluteDate <= lc.AbsoluteFromGregorian(lc.GregorianDate{Year: maxYear, Month: 12, Day: 31})
+}
+
+// newHandler returns the handler serving all endpoints.
+func newHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /calendars", handleCalendars)
+	mux.HandleFunc("GET /convert", handleConvert)
+	mux.HandleFunc("POST /convert", handleConvert)
+	mux.HandleFunc("GET /holidays", handleHolidays)
+	mux.HandleFunc("GET /validate", handleValidate)
+	mux.HandleFunc("POST /validate", handleValidate)
+	mux.HandleFunc("GET /openapi.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(openAPI)
+	})
+	return mux
+}
+
+// conversion is the response of /convert.
+type conversion struct {
+	Absolute float64           `json:"absolute"`
+	Dates    []json.RawMessage `json:"dates"`
+}
+
+// holiday is an element of the response of /holidays.
+type holiday struct {
+	Name     string          `json:"name"`
+	Absolute float64         `json:"absolute"`
+	Date     json.RawMessage `json:"date"`
+}
+
+// validation is the response of /validate.
+type validation struct {
+	Valid bool            `json:"valid"`
+	Date  json.RawMessage `json:"date,omitempty"`
+	Error string          `json:"error,omitempty"`
+}
+
+// writeJSON writes a value as JSON with a given status code.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		status = http.StatusInternalServerError
+		body = []byte(`{"error":"cannot encode response"}`)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(append(body, '\n'))
+}
+
+// writeError writes an error message as JSON with a given status code.
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+// dateJSON returns the Date JSON format of a date, or null if the date
+// cannot be represented, e.g. because it precedes the calendar's epoch.
+func dateJSON(d lc.Date) json.RawMessage {
+	if d.Calendar == "" {
+		return json.RawMessage("null")
+	}
+	for _, x := range d.Components {
+		if math.IsNaN(x) || math.IsInf(x, 0) {
+			return json.RawMessage("null")
+		}
+	}
+	return json.RawMessage(d.Json())
+}
+
+// isCalendar returns true if a calendar is supported.
+func isCalendar(calendar string) bool {
+	for _, c := range lc.Calendars() {
+		if c == calendar {
+			return true
+		}
+	}
+	return false
+}
+
+// requestDate returns the date of a request, given either as a JSON Date in
+// the body of a POST request, or by the query parameters "calendar" and
+// "components" (comma-separated) of a GET request.
+func requestDate(r *http.Request) (lc.Date, error) {
+	var d lc.Date
+	if r.Method == http.MethodPost {
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxBodySize))
+		if err != nil {
+			return lc.Date{}, err
+		}
+		if !json.Valid(body) {
+			return lc.Date{}, errors.New("invalid JSON")
+		}
+		var date struct {
+			Calendar   string    `json:"calendar"`
+			Components []float64 `json:"components"`
+		}
+		if err := json.Unmarshal(body, &date); err != nil {
+			return lc.Date{}, errors.New("invalid JSON Date")
+		}
+		d.Calendar, d.Components = date.Calendar, date.Components
+	} else {
+		q := r.URL.Query()
+		d.Calendar = q.Get("calendar")
+		for _, s := range strings.Split(q.Get("components"), ",") {
+			x, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+			if err != nil {
+				return lc.Date{}, fmt.Errorf("invalid component %q", s)
+			}
+			d.Components = append(d.Components, x)
+		}
+	}
+	if !isCalendar(d.Calendar) {
+		return lc.Date{}, lc.ErrUnknownCalendar
+	}
+	return d, nil
+}
+
+// handleCalendars lists the supported calendars.
+func handleCalendars(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, lc.Calendars())
+}
+
+// handleConvert converts a date into the calendars given by the query
+// parameter "to" (comma-separated, default all).
+func handleConvert(w http.ResponseWriter, r *http.Request) {
+	d, err := requestDate(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	to := lc.Calendars()
+	if s := r.URL.Query().Get("to"); s != "" && s != "all" {
+		to = strings.Split(s, ",")
+	}
+	for _, c := range to {
+		if !isCalendar(c) {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("%w %q", lc.ErrUnknownCalendar, c))
+			return
+		}
+	}
+	if err := lc.ValidateDate(d); err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+	absoluteDate := lc.AbsoluteFromDate(d)
+	if math.IsNaN(absoluteDate) {
+		writeError(w, http.StatusUnprocessableEntity,
+			fmt.Errorf("cannot convert from calendar %q", d.Calendar))
+		return
+	}
+	if !inRange(absoluteDate) {
+		writeError(w, http.StatusUnprocessableEntity, errOutOfRange)
+		return
+	}
+	result := conversion{Absolute: absoluteDate, Dates: []json.RawMessage{}}
+	for _, c := range to {
+		result.Dates = append(result.Dates, dateJSON(lc.DateFromAbsolute(absoluteDate, c)))
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+// parseGregorian parses a Gregorian date written as year-month-day and
+// returns its absolute date.
+func parseGregorian(s string) (float64, error) {
+	var year, month, day int
+	if _, err := fmt.Sscanf(s, "%d-%d-%d", &year, &month, &day); err != nil {
+		return 0, fmt.Errorf("invalid date %q", s)
+	}
+	d := lc.GregorianDate{Year: float64(year), Month: float64(month), Day: float64(day)}
+	if lc.ValidateDate(d.Date()) != nil {
+		return 0, fmt.Errorf("invalid date %q", s)
+	}
+	absoluteDate := lc.AbsoluteFromGregorian(d)
+	if !inRange(absoluteDate) {
+		return 0, errOutOfRange
+	}
+	return absoluteDate, nil
+}
+
+// handleHolidays lists the holidays of the Gregorian year given by the query
+// parameter "year", or from the Gregorian date "from" through "to".
+func handleHolidays(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	var holidays []lc.Holiday
+	switch {
+	case q.Has("year"):
+		year, err := strconv.Atoi(q.Get("year"))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid year %q", q.Get("year")))
+			return
+		}
+		if year < minYear || year > maxYear {
+			writeError(w, http.StatusBadRequest, errOutOfRange)
+			return
+		}
+		holidays = lc.HolidaysInGregorianYear(float64(year))
+	case q.Has("from") && q.Has("to"):
+		from, err := parseGregorian(q.Get("from"))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		to, err := parseGregorian(q.Get("to"))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		if to < from || to-from > 366*100 {
+			writeError(w, http.StatusBadRequest, errors.New("invalid range"))
+			return
+		}
+		holidays = lc.HolidaysInRange(from, to)
+	default:
+		writeError(w, http.StatusBadRequest, errors.New(`either "year" or "from" and "to" are required`))
+		return
+	}
+	result := make([]holiday, 0, len(holidays))
+	for _, h := range holidays {
+		result = append(result, holiday{h.Name, h.Date, dateJSON(lc.DateFromAbsolute(h.Date, "gregorian"))})
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+// handleValidate checks whether a date exists in its calendar.
+func handleValidate(w http.ResponseWriter, r *http.Request) {
+	d, err := requestDate(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if err := lc.ValidateDate(d); err != nil {
+		writeJSON(w, http.StatusOK, validation{Error: err.Error()})
+		return
+	}
+	writeJSON(w, http.StatusOK, validation{Valid: true, Date: dateJSON(lc.DateFromComponents(d.Calendar, d.Components))})
+}
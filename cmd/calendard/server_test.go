@@ -0,0 +1,127 @@
+// Copyright (C) 2022  Alexander Staudt
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestServer(t *testing.T) {
+	server := httptest.NewServer(newHandler())
+	defer server.Close()
+
+	var tests = []struct {
+		method string
+		path   string
+		body   string
+		status int
+		want   []string
+	}{
+		{"GET", "/calendars", "", 200, []string{`"gregorian"`, `"oldHinduLunar"`}},
+		{"GET", "/convert?calendar=gregorian&components=2022,6,15&to=hebrew,islamic", "", 200, []string{`{"absolute":738321,"dates":[{"calendar":"hebrew","components":[5782,3,16]`, `"calendar":"islamic","components":[1443,11,15]`}},
+		{"POST", "/convert?to=gregorian", `{"calendar":"hebrew","components":[5782,9,25]}`, 200, []string{`"components":[2021,11,29]`, `"weekday":"Monday"`}},
+		{"POST", "/convert", `{"calendar":"mayanLongCount","components":[13,0,9,11,3]}`, 200, []string{`"calendar":"french","components":[230,9,27]`}},
+		{"POST", "/convert", `{"calendar":"mayanHaab","components":[16,4]}`, 422, []string{`"error":"cannot convert from calendar \"mayanHaab\""`}},
+		{"GET", "/convert?calendar=gregorian&components=2022,2,30", "", 422, []string{`"error":"libcalendar: invalid date"`}},
+		{"GET", "/convert?calendar=aztec&components=1,1,1", "", 400, []string{`"error":"libcalendar: unknown calendar"`}},
+		{"GET", "/convert?calendar=gregorian&components=2022,6,15&to=aztec", "", 400, []string{`unknown calendar \"aztec\"`}},
+		{"GET", "/convert?calendar=gregorian&components=2022,June,15", "", 400, []string{`invalid component`}},
+		{"GET", "/convert?calendar=unixDay&components=1e15", "", 422, []string{`"error":"date out of range (Gregorian years -9999 to 9999)"`}},
+		{"GET", "/convert?calendar=gregorian&components=10000,1,1", "", 422, []string{`date out of range`}},
+		{"GET", "/convert?calendar=gregorian&components=2022,14,1", "", 422, []string{`"error":"libcalendar: invalid date"`}},
+		{"GET", "/convert?calendar=hinduSolar&components=-1e15,1,1", "", 422, []string{`"error":"libcalendar: invalid date"`}},
+		{"POST", "/convert", `{"calendar":`, 400, []string{`"error":"invalid JSON"`}},
+		{"POST", "/convert", `{"note":"calendar"}`, 400, []string{`"error":"libcalendar: unknown calendar"`}},
+		{"POST", "/convert", `{"calendar":{}}`, 400, []string{`"error":"invalid JSON Date"`}},
+		{"POST", "/convert", `{"calendar":"gregorian","components":"2022-6-15"}`, 400, []string{`"error":"invalid JSON Date"`}},
+		{"POST", "/validate", `{"components":[1,2,3],"calendar"}`, 400, []string{`"error":"invalid JSON"`}},
+		{"GET", "/holidays?year=2022", "", 200, []string{`{"name":"Christmas","absolute":738514,"date":{"calendar":"gregorian","components":[2022,12,25]`}},
+		{"GET", "/holidays?from=2022-6-1&to=2022-6-30", "", 200, []string{`[{"name":"Pentecost","absolute":738311`}},
+		{"GET", "/holidays?from=2022-6-30&to=2022-6-1", "", 400, []string{`invalid range`}},
+		{"GET", "/holidays?from=2022-2-30&to=2022-6-1", "", 400, []string{`invalid date \"2022-2-30\"`}},
+		{"GET", "/holidays?year=10000000", "", 400, []string{`date out of range`}},
+		{"GET", "/holidays?year=-9999", "", 200, []string{`"components":[-9999,12,25]`}},
+		{"GET", "/holidays?from=9999-12-1&to=10000-1-31", "", 400, []string{`date out of range`}},
+		{"GET", "/holidays", "", 400, []string{`required`}},
+		{"GET", "/validate?calendar=gregorian&components=2024,2,29", "", 200, []string{`{"valid":true,"date":{"calendar":"gregorian","components":[2024,2,29]`}},
+		{"POST", "/validate", `{"calendar":"hebrew","components":[5783,13,1]}`, 200, []string{`{"valid":false,"error":"libcalendar: invalid date"}`}},
+		{"POST", "/validate", `{"calendar":"mayanTzolkin","components":[13,20]}`, 200, []string{`"valid":true`}},
+		{"GET", "/validate?calendar=bahai&components=179,5,11", "", 200, []string{`"valid":true`}},
+		{"DELETE", "/convert", "", 405, nil},
+		{"GET", "/openapi.json", "", 200, []string{`"openapi": "3.0.3"`}},
+	}
+	for _, tt := range tests {
+		testname := tt.method + " " + tt.path
+		t.Run(testname, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, server.URL+tt.path, strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+			var body bytes.Buffer
+			if _, err := body.ReadFrom(resp.Body); err != nil {
+				t.Fatal(err)
+			}
+			t.Logf("got %v, want %v", resp.StatusCode, tt.status)
+			if resp.StatusCode != tt.status {
+				t.Errorf("got %v, want %v (%s)", resp.StatusCode, tt.status, body.String())
+			}
+			if tt.want != nil && !json.Valid(body.Bytes()) {
+				t.Errorf("invalid JSON %s", body.String())
+			}
+			for _, w := range tt.want {
+				if !strings.Contains(body.String(), w) {
+					t.Errorf("response %s does not contain %s", body.String(), w)
+				}
+			}
+		})
+	}
+}
+
+// TestOpenAPI checks that every operation of the OpenAPI description is
+// served.
+func TestOpenAPI(t *testing.T) {
+	var spec struct {
+		Paths map[string]map[string]any `json:"paths"`
+	}
+	if err := json.Unmarshal(openAPI, &spec); err != nil {
+		t.Fatal(err)
+	}
+	handler := newHandler()
+	for path, operations := range spec.Paths {
+		for method := range operations {
+			testname := method + " " + path
+			t.Run(testname, func(t *testing.T) {
+				req := httptest.NewRequest(strings.ToUpper(method), path, nil)
+				rec := httptest.NewRecorder()
+				handler.ServeHTTP(rec, req)
+				t.Logf("got %v", rec.Code)
+				if rec.Code == http.StatusNotFound || rec.Code == http.StatusMethodNotAllowed {
+					t.Errorf("got %v", rec.Code)
+				}
+			})
+		}
+	}
+}
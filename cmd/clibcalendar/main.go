@@ -0,0 +1,370 @@
+// Copyright (C) 2022  Alexander Staudt
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Command clibcalendar builds libcalendar as a C shared library.
+//
+//	go build -buildmode=c-shared -o libcalendar.so ./cmd/clibcalendar
+//
+// generates libcalendar.so and the header libcalendar.h, which declares the
+// date structs (lc_gregorian_date etc.) and the lc_* functions. Dates are
+// passed by value; absolute (fixed) dates are doubles.
+//
+// Memory ownership: strings (char *) returned by the library are allocated
+// with malloc and owned by the caller, who must release them with
+// lc_free_string. Arrays of holidays must be released with lc_free_holidays,
+// which also frees the holiday names. Strings passed to the library remain
+// owned by the caller and are not retained; as cgo declares them char *,
+// C++ callers pass string literals with a const_cast.
+//
+// Input the library cannot handle, e.g. a month 14, does not crash the host:
+// functions then return NaN for absolute dates and date components, 0 for
+// booleans, and NULL (with a count of 0) for strings and arrays.
+//
+// The Makefile builds the library and runs the C test program in testdata.
+package main
+
+//go:generate go build -buildmode=c-shared -o libcalendar.so .
+
+/*
+#include <stdlib.h>
+
+typedef struct { double year, month, day; } lc_gregorian_date;
+typedef struct { double year, week, day; } lc_iso_date;
+typedef struct { double year, month, day; } lc_julian_date;
+typedef struct { double year, month, day; } lc_islamic_date;
+typedef struct { double year, month, day; } lc_hebrew_date;
+typedef struct { double baktun, katun, tun, uinal, kin; } lc_mayan_long_count;
+typedef struct { double day, month; } lc_mayan_haab_date;
+typedef struct { double number, name; } lc_mayan_tzolkin_date;
+typedef struct { double year, month, day; } lc_french_date;
+typedef struct { double year, month, day; } lc_old_hindu_solar_date;
+typedef struct { double year, month; int leap_month; double day; } lc_old_hindu_lunar_date;
+
+typedef struct {
+	char *name;  // owned by the array, freed by lc_free_holidays
+	double date; // absolute (fixed) date
+} lc_holiday;
+*/
+import "C"
+
+import (
+	"math"
+	"unsafe"
+
+	lc "staudtlex.de/libcalendar"
+)
+
+func main() {}
+
+// nan is the absolute date, or date component, returned for dates that
+// cannot be converted.
+var nan = C.double(math.NaN())
+
+// guard is deferred by every exported function calling into the library, so
+// that a panic, e.g. on malformed or out-of-range input, does not cross into
+// the C host: the function then returns fallback instead.
+func guard[T any](result *T, fallback T) {
+	if recover() != nil {
+		*result = fallback
+	}
+}
+
+// Gregorian calendar
+
+//export lc_absolute_from_gregorian
+func lc_absolute_from_gregorian(d C.lc_gregorian_date) (absoluteDate C.double) {
+	defer guard(&absoluteDate, nan)
+	return C.double(lc.AbsoluteFromGregorian(lc.GregorianDate{Year: float64(d.year), Month: float64(d.month), Day: float64(d.day)}))
+}
+
+//export lc_gregorian_from_absolute
+func lc_gregorian_from_absolute(absoluteDate C.double) (date C.lc_gregorian_date) {
+	defer guard(&date, C.lc_gregorian_date{nan, nan, nan})
+	d := lc.GregorianFromAbsolute(float64(absoluteDate))
+	return C.lc_gregorian_date{C.double(d.Year), C.double(d.Month), C.double(d.Day)}
+}
+
+// ISO calendar
+
+//export lc_absolute_from_iso
+func lc_absolute_from_iso(d C.lc_iso_date) (absoluteDate C.double) {
+	defer guard(&absoluteDate, nan)
+	return C.double(lc.AbsoluteFromIso(lc.IsoDate{Year: float64(d.year), Week: float64(d.week), Day: float64(d.day)}))
+}
+
+//export lc_iso_from_absolute
+func lc_iso_from_absolute(absoluteDate C.double) (date C.lc_iso_date) {
+	defer guard(&date, C.lc_iso_date{nan, nan, nan})
+	d := lc.IsoFromAbsolute(float64(absoluteDate))
+	return C.lc_iso_date{C.double(d.Year), C.double(d.Week), C.double(d.Day)}
+}
+
+// Julian calendar
+
+//export lc_absolute_from_julian
+func lc_absolute_from_julian(d C.lc_julian_date) (absoluteDate C.double) {
+	defer guard(&absoluteDate, nan)
+	return C.double(lc.AbsoluteFromJulian(lc.JulianDate{Year: float64(d.year), Month: float64(d.month), Day: float64(d.day)}))
+}
+
+//export lc_julian_from_absolute
+func lc_julian_from_absolute(absoluteDate C.double) (date C.lc_julian_date) {
+	defer guard(&date, C.lc_julian_date{nan, nan, nan})
+	d := lc.JulianFromAbsolute(float64(absoluteDate))
+	return C.lc_julian_date{C.double(d.Year), C.double(d.Month), C.double(d.Day)}
+}
+
+// Islamic calendar
+
+//export lc_absolute_from_islamic
+func lc_absolute_from_islamic(d C.lc_islamic_date) (absoluteDate C.double) {
+	defer guard(&absoluteDate, nan)
+	return C.double(lc.AbsoluteFromIslamic(lc.IslamicDate{Year: float64(d.year), Month: float64(d.month), Day: float64(d.day)}))
+}
+
+//export lc_islamic_from_absolute
+func lc_islamic_from_absolute(absoluteDate C.double) (date C.lc_islamic_date) {
+	defer guard(&date, C.lc_islamic_date{nan, nan, nan})
+	d := lc.IslamicFromAbsolute(float64(absoluteDate))
+	return C.lc_islamic_date{C.double(d.Year), C.double(d.Month), C.double(d.Day)}
+}
+
+//export lc_islamic_leap_year
+func lc_islamic_leap_year(year C.double) (leap C.int) {
+	defer guard(&leap, 0)
+	return cBool(lc.IslamicLeapYear(float64(year)))
+}
+
+//export lc_last_day_of_islamic_month
+func lc_last_day_of_islamic_month(month, year C.double) (day C.double) {
+	defer guard(&day, nan)
+	return C.double(lc.LastDayOfIslamicMonth(float64(month), float64(year)))
+}
+
+// Hebrew calendar
+
+//export lc_absolute_from_hebrew
+func lc_absolute_from_hebrew(d C.lc_hebrew_date) (absoluteDate C.double) {
+	defer guard(&absoluteDate, nan)
+	return C.double(lc.AbsoluteFromHebrew(hebrewDate(d)))
+}
+
+//export lc_hebrew_from_absolute
+func lc_hebrew_from_absolute(absoluteDate C.double) (date C.lc_hebrew_date) {
+	defer guard(&date, C.lc_hebrew_date{nan, nan, nan})
+	d := lc.HebrewFromAbsolute(float64(absoluteDate))
+	return C.lc_hebrew_date{C.double(d.Year), C.double(d.Month), C.double(d.Day)}
+}
+
+//export lc_hebrew_leap_year
+func lc_hebrew_leap_year(year C.double) (leap C.int) {
+	defer guard(&leap, 0)
+	return cBool(lc.HebrewLeapYear(float64(year)))
+}
+
+//export lc_last_day_of_hebrew_month
+func lc_last_day_of_hebrew_month(month, year C.double) (day C.double) {
+	defer guard(&day, nan)
+	return C.double(lc.LastDayOfHebrewMonth(float64(month), float64(year)))
+}
+
+// Mayan calendars
+
+//export lc_absolute_from_mayan_long_count
+func lc_absolute_from_mayan_long_count(d C.lc_mayan_long_count) (absoluteDate C.double) {
+	defer guard(&absoluteDate, nan)
+	return C.double(lc.AbsoluteFromMayanLongCount(lc.MayanLongCount{
+		Baktun: float64(d.baktun), Katun: float64(d.katun), Tun: float64(d.tun),
+		Uinal: float64(d.uinal), Kin: float64(d.kin),
+	}))
+}
+
+//export lc_mayan_long_count_from_absolute
+func lc_mayan_long_count_from_absolute(absoluteDate C.double) (date C.lc_mayan_long_count) {
+	defer guard(&date, C.lc_mayan_long_count{nan, nan, nan, nan, nan})
+	d := lc.MayanLongCountFromAbsolute(float64(absoluteDate))
+	return C.lc_mayan_long_count{C.double(d.Baktun), C.double(d.Katun), C.double(d.Tun), C.double(d.Uinal), C.double(d.Kin)}
+}
+
+//export lc_mayan_haab_from_absolute
+func lc_mayan_haab_from_absolute(absoluteDate C.double) (date C.lc_mayan_haab_date) {
+	defer guard(&date, C.lc_mayan_haab_date{nan, nan})
+	d := lc.MayanHaabFromAbsolute(float64(absoluteDate))
+	return C.lc_mayan_haab_date{C.double(d.Day), C.double(d.Month)}
+}
+
+//export lc_mayan_tzolkin_from_absolute
+func lc_mayan_tzolkin_from_absolute(absoluteDate C.double) (date C.lc_mayan_tzolkin_date) {
+	defer guard(&date, C.lc_mayan_tzolkin_date{nan, nan})
+	d := lc.MayanTzolkinFromAbsolute(float64(absoluteDate))
+	return C.lc_mayan_tzolkin_date{C.double(d.Number), C.double(d.Name)}
+}
+
+// French Revolutionary calendar
+
+//export lc_absolute_from_french
+func lc_absolute_from_french(d C.lc_french_date) (absoluteDate C.double) {
+	defer guard(&absoluteDate, nan)
+	return C.double(lc.AbsoluteFromFrench(lc.FrenchDate{Year: float64(d.year), Month: float64(d.month), Day: float64(d.day)}))
+}
+
+//export lc_french_from_absolute
+func lc_french_from_absolute(absoluteDate C.double) (date C.lc_french_date) {
+	defer guard(&date, C.lc_french_date{nan, nan, nan})
+	d := lc.FrenchFromAbsolute(float64(absoluteDate))
+	return C.lc_french_date{C.double(d.Year), C.double(d.Month), C.double(d.Day)}
+}
+
+// Old Hindu calendars
+
+//export lc_absolute_from_old_hindu_solar
+func lc_absolute_from_old_hindu_solar(d C.lc_old_hindu_solar_date) (absoluteDate C.double) {
+	defer guard(&absoluteDate, nan)
+	return C.double(lc.AbsoluteFromOldHinduSolar(lc.OldHinduSolarDate{Year: float64(d.year), Month: float64(d.month), Day: float64(d.day)}))
+}
+
+//export lc_old_hindu_solar_from_absolute
+func lc_old_hindu_solar_from_absolute(absoluteDate C.double) (date C.lc_old_hindu_solar_date) {
+	defer guard(&date, C.lc_old_hindu_solar_date{nan, nan, nan})
+	d := lc.OldHinduSolarFromAbsolute(float64(absoluteDate))
+	return C.lc_old_hindu_solar_date{C.double(d.Year), C.double(d.Month), C.double(d.Day)}
+}
+
+//export lc_absolute_from_old_hindu_lunar
+func lc_absolute_from_old_hindu_lunar(d C.lc_old_hindu_lunar_date) (absoluteDate C.double) {
+	defer guard(&absoluteDate, nan)
+	return C.double(lc.AbsoluteFromOldHinduLunar(lc.OldHinduLunarDate{
+		Year: float64(d.year), Month: float64(d.month), LeapMonth: d.leap_month != 0, Day: float64(d.day),
+	}))
+}
+
+//export lc_old_hindu_lunar_from_absolute
+func lc_old_hindu_lunar_from_absolute(absoluteDate C.double) (date C.lc_old_hindu_lunar_date) {
+	defer guard(&date, C.lc_old_hindu_lunar_date{nan, nan, 0, nan})
+	d := lc.OldHinduLunarFromAbsolute(float64(absoluteDate))
+	return C.lc_old_hindu_lunar_date{C.double(d.Year), C.double(d.Month), cBool(d.LeapMonth), C.double(d.Day)}
+}
+
+// Holidays
+
+//export lc_hebrew_birthday
+func lc_hebrew_birthday(birthdate C.lc_hebrew_date, year C.double) (absoluteDate C.double) {
+	defer guard(&absoluteDate, nan)
+	return C.double(lc.HebrewBirthday(hebrewDate(birthdate), float64(year)))
+}
+
+//export lc_yahrzeit
+func lc_yahrzeit(deathDate C.lc_hebrew_date, year C.double) (absoluteDate C.double) {
+	defer guard(&absoluteDate, nan)
+	return C.double(lc.Yahrzeit(hebrewDate(deathDate), float64(year)))
+}
+
+//export lc_holidays_in_gregorian_year
+func lc_holidays_in_gregorian_year(year C.double, count *C.int) (holidays *C.lc_holiday) {
+	defer guard(&holidays, nil)
+	cHolidays(nil, count) // no holidays in case of a panic
+	return cHolidays(lc.HolidaysInGregorianYear(float64(year)), count)
+}
+
+//export lc_holidays_in_range
+func lc_holidays_in_range(from, to C.double, count *C.int) (holidays *C.lc_holiday) {
+	defer guard(&holidays, nil)
+	cHolidays(nil, count) // no holidays in case of a panic
+	return cHolidays(lc.HolidaysInRange(float64(from), float64(to)), count)
+}
+
+//export lc_free_holidays
+func lc_free_holidays(holidays *C.lc_holiday, count C.int) {
+	if holidays == nil || count < 0 {
+		return
+	}
+	for _, h := range unsafe.Slice(holidays, int(count)) {
+		C.free(unsafe.Pointer(h.name))
+	}
+	C.free(unsafe.Pointer(holidays))
+}
+
+// Strings
+
+//export lc_from_absolute
+func lc_from_absolute(absoluteDate C.double, calendar *C.char) (s *C.char) {
+	defer guard(&s, nil)
+	return C.CString(lc.FromAbsolute(float64(absoluteDate), C.GoString(calendar)))
+}
+
+//export lc_format
+func lc_format(absoluteDate C.double, calendar, locale *C.char) (s *C.char) {
+	defer guard(&s, nil)
+	opts := lc.FormatOptions{Locale: C.GoString(locale)}
+	return C.CString(lc.FormatFromAbsolute(float64(absoluteDate), C.GoString(calendar), opts))
+}
+
+//export lc_weekday_name
+func lc_weekday_name(absoluteDate C.double, calendar, locale *C.char) (s *C.char) {
+	defer guard(&s, nil)
+	return C.CString(lc.LocalizedWeekdayName(C.GoString(calendar), float64(absoluteDate), C.GoString(locale)))
+}
+
+//export lc_json_date_from_absolute
+func lc_json_date_from_absolute(absoluteDate C.double, calendar *C.char) (s *C.char) {
+	defer guard(&s, nil)
+	return C.CString(lc.JsonDateFromAbsolute(float64(absoluteDate), C.GoString(calendar)))
+}
+
+//export lc_absolute_from_json_date
+func lc_absolute_from_json_date(json *C.char) (absoluteDate C.double) {
+	defer guard(&absoluteDate, nan)
+	d := lc.JsonToDate(C.GoString(json))
+	d = lc.DateFromComponents(d.Calendar, d.Components)
+	if d.Calendar == "" {
+		return nan
+	}
+	return C.double(lc.AbsoluteFromDate(d))
+}
+
+//export lc_free_string
+func lc_free_string(s *C.char) {
+	C.free(unsafe.Pointer(s))
+}
+
+// cBool converts a bool to a C int (1 or 0).
+func cBool(b bool) C.int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// hebrewDate converts a C Hebrew date.
+func hebrewDate(d C.lc_hebrew_date) lc.HebrewDate {
+	return lc.HebrewDate{Year: float64(d.year), Month: float64(d.month), Day: float64(d.day)}
+}
+
+// cHolidays copies holidays into a malloc'ed array and stores their number
+// in count. It returns NULL if there are no holidays.
+func cHolidays(holidays []lc.Holiday, count *C.int) *C.lc_holiday {
+	if count != nil {
+		*count = C.int(len(holidays))
+	}
+	if len(holidays) == 0 {
+		return nil
+	}
+	array := (*C.lc_holiday)(C.malloc(C.size_t(len(holidays)) * C.size_t(unsafe.Sizeof(C.lc_holiday{}))))
+	elems := unsafe.Slice(array, len(holidays))
+	for i, h := range holidays {
+		elems[i] = C.lc_holiday{name: C.CString(h.Name), date: C.double(h.Date)}
+	}
+	return array
+}
@@ -0,0 +1,52 @@
+// Copyright (C) 2022  Alexander Staudt
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// TestCProgram builds the shared library, compiles testdata/lc_test.c against
+// it, and runs the resulting C program.
+func TestCProgram(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping C build in short mode")
+	}
+	cc := os.Getenv("CC")
+	if cc == "" {
+		cc = "cc"
+	}
+	if _, err := exec.LookPath(cc); err != nil {
+		t.Skipf("no C compiler: %v", err)
+	}
+	dir := t.TempDir()
+	steps := [][]string{
+		{"go", "build", "-buildmode=c-shared", "-o", filepath.Join(dir, "libcalendar.so"), "."},
+		{cc, "-Wall", "-I", dir, "-o", filepath.Join(dir, "lc_test"), filepath.Join("testdata", "lc_test.c"),
+			"-L", dir, "-lcalendar", "-Wl,-rpath," + dir},
+		{filepath.Join(dir, "lc_test")},
+	}
+	for _, step := range steps {
+		out, err := exec.Command(step[0], step[1:]...).CombinedOutput()
+		t.Logf("%v\n%s", step, out)
+		if err != nil {
+			t.Fatalf("%v: %v", step[0], err)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+/*
+ * Copyright (C) 2022  Alexander Staudt
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+/* lc_test exercises the C API of libcalendar. It prints one line per failed
+ * check and exits with status 1 if any check failed. */
+
+#include <math.h>
+#include <stdio.h>
+#include <string.h>
+
+#include "libcalendar.h"
+
+static int failures = 0;
+
+static void check_number(const char *name, double got, double want) {
+	if (got != want) {
+		printf("%s: got %g, want %g\n", name, got, want);
+		failures++;
+	}
+}
+
+static void check_nan(const char *name, double got) {
+	if (!isnan(got)) {
+		printf("%s: got %g, want NaN\n", name, got);
+		failures++;
+	}
+}
+
+/* check_string compares and frees a string returned by the library. */
+static void check_string(const char *name, char *got, const char *want) {
+	if (got == NULL || strcmp(got, want) != 0) {
+		printf("%s: got \"%s\", want \"%s\"\n", name, got ? got : "(null)", want);
+		failures++;
+	}
+	lc_free_string(got);
+}
+
+int main(void) {
+	lc_gregorian_date g = {2022, 6, 15};
+	double rd = lc_absolute_from_gregorian(g);
+	check_number("lc_absolute_from_gregorian", rd, 738321);
+
+	lc_gregorian_date g2 = lc_gregorian_from_absolute(rd);
+	check_number("lc_gregorian_from_absolute", g2.year * 10000 + g2.month * 100 + g2.day, 20220615);
+
+	lc_iso_date iso = lc_iso_from_absolute(rd);
+	check_number("lc_iso_from_absolute", iso.week, 24);
+	check_number("lc_absolute_from_iso", lc_absolute_from_iso(iso), rd);
+
+	lc_julian_date j = lc_julian_from_absolute(rd);
+	check_number("lc_julian_from_absolute", j.day, 2);
+	check_number("lc_absolute_from_julian", lc_absolute_from_julian(j), rd);
+
+	lc_hebrew_date h = lc_hebrew_from_absolute(rd);
+	check_number("lc_hebrew_from_absolute", h.year * 10000 + h.month * 100 + h.day, 57820316);
+	check_number("lc_absolute_from_hebrew", lc_absolute_from_hebrew(h), rd);
+	check_number("lc_hebrew_leap_year", lc_hebrew_leap_year(5782), 1);
+	check_number("lc_last_day_of_hebrew_month", lc_last_day_of_hebrew_month(13, 5782), 29);
+
+	lc_islamic_date i = lc_islamic_from_absolute(rd);
+	check_number("lc_islamic_from_absolute", i.year * 10000 + i.month * 100 + i.day, 14431115);
+	check_number("lc_absolute_from_islamic", lc_absolute_from_islamic(i), rd);
+	check_number("lc_islamic_leap_year", lc_islamic_leap_year(1443), 0);
+
+	lc_mayan_long_count m = lc_mayan_long_count_from_absolute(rd);
+	check_number("lc_mayan_long_count_from_absolute", m.uinal * 100 + m.kin, 1103);
+	check_number("lc_absolute_from_mayan_long_count", lc_absolute_from_mayan_long_count(m), rd);
+	check_number("lc_mayan_haab_from_absolute", lc_mayan_haab_from_absolute(rd).day, 16);
+	check_number("lc_mayan_tzolkin_from_absolute", lc_mayan_tzolkin_from_absolute(rd).number, 9);
+
+	lc_french_date f = lc_french_from_absolute(rd);
+	check_number("lc_french_from_absolute", f.year * 10000 + f.month * 100 + f.day, 2300927);
+	check_number("lc_absolute_from_french", lc_absolute_from_french(f), rd);
+
+	lc_old_hindu_solar_date s = lc_old_hindu_solar_from_absolute(rd);
+	check_number("lc_absolute_from_old_hindu_solar", lc_absolute_from_old_hindu_solar(s), rd);
+	lc_old_hindu_lunar_date l = lc_old_hindu_lunar_from_absolute(rd);
+	check_number("lc_old_hindu_lunar_from_absolute", l.leap_month, 0);
+	check_number("lc_absolute_from_old_hindu_lunar", lc_absolute_from_old_hindu_lunar(l), rd);
+
+	lc_hebrew_date birthdate = {5782, 13, 30};
+	check_number("lc_hebrew_birthday", lc_hebrew_birthday(birthdate, 5783), lc_absolute_from_hebrew((lc_hebrew_date){5783, 12, 30}));
+	check_number("lc_yahrzeit", lc_yahrzeit((lc_hebrew_date){5782, 3, 16}, 5783), lc_absolute_from_hebrew((lc_hebrew_date){5783, 3, 16}));
+
+	int count = 0;
+	lc_holiday *holidays = lc_holidays_in_gregorian_year(2022, &count);
+	int found = 0;
+	for (int k = 0; k < count; k++) {
+		if (strcmp(holidays[k].name, "Passover") == 0) {
+			check_number("Passover", holidays[k].date, 738261);
+			found = 1;
+		}
+	}
+	check_number("lc_holidays_in_gregorian_year", found, 1);
+	lc_free_holidays(holidays, count);
+
+	holidays = lc_holidays_in_range(738320, 738300, &count);
+	check_number("lc_holidays_in_range", count, 0);
+	lc_free_holidays(holidays, count);
+
+	check_string("lc_from_absolute", lc_from_absolute(rd, "hebrew"), "16 Sivan 5782");
+	check_string("lc_format", lc_format(rd, "gregorian", "de"), "15. Juni 2022");
+	check_string("lc_weekday_name", lc_weekday_name(rd, "gregorian", "fr"), "mercredi");
+	char *json = lc_json_date_from_absolute(rd, "islamic");
+	check_number("lc_absolute_from_json_date", lc_absolute_from_json_date(json), rd);
+	lc_free_string(json);
+	check_nan("lc_absolute_from_json_date (no calendar)", lc_absolute_from_json_date("{\"note\":\"calendar\"}"));
+	check_nan("lc_absolute_from_json_date (object)", lc_absolute_from_json_date("{\"calendar\":{}}"));
+	check_nan("lc_absolute_from_json_date (truncated)", lc_absolute_from_json_date("{\"calendar\":\"gregorian\",\"components\":[2022"));
+	check_nan("lc_absolute_from_json_date (empty)", lc_absolute_from_json_date(""));
+	lc_free_string(NULL);
+
+	/* input the library cannot handle yields NaN or NULL instead of a panic */
+	check_nan("lc_absolute_from_gregorian (month 14)", lc_absolute_from_gregorian((lc_gregorian_date){2022, 14, 1}));
+	check_nan("lc_absolute_from_json_date (month 14)", lc_absolute_from_json_date("{\"calendar\":\"gregorian\",\"components\":[2022,14,1]}"));
+	count = -1;
+	holidays = lc_holidays_in_gregorian_year(1e7, &count);
+	check_number("lc_holidays_in_gregorian_year (1e7)", (holidays == NULL) * 10 + count, 10);
+	lc_free_holidays(holidays, count);
+	lc_free_holidays(NULL, -1);
+
+	if (failures > 0) {
+		printf("FAIL: %d checks failed\n", failures);
+		return 1;
+	}
+	printf("PASS\n");
+	return 0;
+}
@@ -0,0 +1,412 @@
+// Copyright (C) 2022  Alexander Staudt
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Command libcalendar converts dates between the calendars supported by
+// libcalendar, and lists holidays.
+//
+// Usage:
+//
+//	libcalendar [flags] [date]
+//
+// The date is given as its components in the order of the calendar's
+// Date.Components, separated by blanks, commas, slashes, dots or dashes,
+// e.g. "2022-6-15" (Gregorian), "5782 9 25" (Hebrew) or "13.0.9.11.14"
+// (Mayan long count). If no date is given, dates are read from standard
+// input, one per line.
+//
+// Examples:
+//
+//	libcalendar 2022-6-15
+//	libcalendar -from hebrew -to gregorian,islamic 5782 9 25
+//	libcalendar -from absolute -format json 738321
+//	libcalendar -holidays 2022 -format csv
+//	libcalendar -grid month -to hebrew -secondary gregorian -mark-holidays 2022-6-15
+//	libcalendar -grid year -to french 2022-6-15
+//	cat dates.txt | libcalendar -to julian
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	lc "staudtlex.de/libcalendar"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// options holds the command line flags.
+type options struct {
+	from      string
+	to        []string
+	format    string
+	holidays  string
+	calendars bool
+	grid      string
+	secondary string
+	mark      bool
+}
+
+// run executes the command with the given arguments and streams, and returns
+// the exit code.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("libcalendar", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: libcalendar [flags] [date]")
+		fs.PrintDefaults()
+	}
+	var opts options
+	var to string
+	fs.StringVar(&opts.from, "from", "gregorian", `calendar of the input dates, or "absolute" for absolute (fixed) dates`)
+	fs.StringVar(&to, "to", "all", `comma-separated list of calendars to convert to, or "all"`)
+	fs.StringVar(&opts.format, "format", "text", "output format: text, json or csv")
+	fs.StringVar(&opts.holidays, "holidays", "", "list the holidays of a Gregorian `year`")
+	fs.BoolVar(&opts.calendars, "calendars", false, "list the supported calendars")
+	fs.StringVar(&opts.grid, "grid", "", `print the "month" or "year" of the date as a grid, in the calendar given by -to (or -from)`)
+	fs.StringVar(&opts.secondary, "secondary", "", "annotate grid days with the day numbers of a `calendar`")
+	fs.BoolVar(&opts.mark, "mark-holidays", false, "mark holidays in grids")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	switch {
+	case opts.grid != "" && to == "all":
+		opts.to = []string{opts.from}
+		if opts.from == "absolute" {
+			opts.to = []string{"gregorian"}
+		}
+	case to == "all":
+		opts.to = lc.Calendars()
+	default:
+		opts.to = strings.Split(to, ",")
+	}
+	if err := opts.validate(); err != nil {
+		fmt.Fprintln(stderr, "libcalendar:", err)
+		return 2
+	}
+
+	out, err := newWriter(opts.format, stdout)
+	if err != nil {
+		fmt.Fprintln(stderr, "libcalendar:", err)
+		return 2
+	}
+	defer out.flush()
+
+	switch {
+	case opts.calendars:
+		for _, c := range lc.Calendars() {
+			fmt.Fprintln(stdout, c)
+		}
+		return 0
+	case opts.holidays != "":
+		year, err := strconv.ParseFloat(opts.holidays, 64)
+		if err != nil {
+			fmt.Fprintf(stderr, "libcalendar: invalid year %q\n", opts.holidays)
+			return 2
+		}
+		out.holidays(lc.HolidaysInGregorianYear(year))
+		return 0
+	case opts.grid != "" && fs.NArg() > 0:
+		return grid(strings.Join(fs.Args(), " "), opts, stdout, stderr)
+	case fs.NArg() > 0:
+		return convert(strings.Join(fs.Args(), " "), opts, out, stderr)
+	}
+
+	status := 0
+	scanner := bufio.NewScanner(stdin)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		s := 0
+		if opts.grid != "" {
+			s = grid(line, opts, stdout, stderr)
+		} else {
+			s = convert(line, opts, out, stderr)
+		}
+		if s != 0 {
+			status = s
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		fmt.Fprintln(stderr, "libcalendar:", err)
+		return 1
+	}
+	return status
+}
+
+// validate checks that the calendar names and output format are supported.
+func (o options) validate() error {
+	isCalendar := func(c string) bool {
+		for _, name := range lc.Calendars() {
+			if c == name {
+				return true
+			}
+		}
+		return false
+	}
+	if o.from != "absolute" && !isCalendar(o.from) {
+		return fmt.Errorf("unknown calendar %q", o.from)
+	}
+	for _, c := range o.to {
+		if !isCalendar(c) {
+			return fmt.Errorf("unknown calendar %q", c)
+		}
+	}
+	if o.secondary != "" && !isCalendar(o.secondary) {
+		return fmt.Errorf("unknown calendar %q", o.secondary)
+	}
+	switch o.grid {
+	case "", "month", "year":
+	default:
+		return fmt.Errorf("unknown grid %q", o.grid)
+	}
+	if o.grid != "" && len(o.to) != 1 {
+		return errors.New("grids are printed for a single calendar")
+	}
+	switch o.format {
+	case "text", "json", "csv":
+		return nil
+	default:
+		return fmt.Errorf("unknown format %q", o.format)
+	}
+}
+
+// convert converts a single date given as a string and writes the result. It
+// returns the exit code.
+func convert(input string, opts options, out writer, stderr io.Writer) int {
+	absoluteDate, err := parseDate(input, opts.from)
+	if err != nil {
+		fmt.Fprintf(stderr, "libcalendar: %q: %v\n", input, err)
+		return 1
+	}
+	dates := make([]lc.Date, 0, len(opts.to))
+	for _, c := range opts.to {
+		dates = append(dates, lc.DateFromAbsolute(absoluteDate, c))
+	}
+	out.dates(input, absoluteDate, dates)
+	return 0
+}
+
+// grid prints the month or year grid of a date given as a string. It returns
+// the exit code.
+func grid(input string, opts options, stdout, stderr io.Writer) int {
+	absoluteDate, err := parseDate(input, opts.from)
+	if err != nil {
+		fmt.Fprintf(stderr, "libcalendar: %q: %v\n", input, err)
+		return 1
+	}
+	calendar := opts.to[0]
+	gridOpts := lc.GridOptions{Secondary: opts.secondary, Holidays: opts.mark}
+	var grids []lc.Grid
+	if opts.grid == "month" {
+		var g lc.Grid
+		g, err = lc.MonthGrid(calendar, absoluteDate, gridOpts)
+		grids = []lc.Grid{g}
+	} else {
+		// ISO year grids show the months of the Gregorian year
+		yearCalendar := calendar
+		if calendar == "iso" {
+			yearCalendar = "gregorian"
+		}
+		year, _ := lc.DateFromAbsolute(absoluteDate, yearCalendar).Year()
+		grids, err = lc.YearGrid(calendar, year, gridOpts)
+	}
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	fmt.Fprint(stdout, lc.JoinGrids(grids, 3))
+	return 0
+}
+
+// parseDate returns the absolute date of a date given as a string of
+// components in a given calendar.
+func parseDate(input string, calendar string) (float64, error) {
+	components, err := parseComponents(input)
+	if err != nil {
+		return 0, err
+	}
+	if calendar == "absolute" {
+		if len(components) != 1 {
+			return 0, errors.New("expected a single absolute date")
+		}
+		return components[0], nil
+	}
+	d := lc.DateFromComponents(calendar, components)
+	if d.Calendar == "" {
+		return 0, fmt.Errorf("wrong number of components for calendar %q", calendar)
+	}
+	absoluteDate := lc.AbsoluteFromDate(d)
+	if math.IsNaN(absoluteDate) {
+		return 0, fmt.Errorf("cannot convert from calendar %q", calendar)
+	}
+	return absoluteDate, nil
+}
+
+// parseComponents splits a string into numeric components. Components are
+// separated by blanks, commas, slashes, dots, or dashes; a dash preceding a
+// number at the start of the string or after another separator is a minus
+// sign. A string holding a single number, e.g. the Julian day "2459745.5", is
+// not split.
+func parseComponents(s string) ([]float64, error) {
+	if n, err := strconv.ParseFloat(strings.TrimSpace(s), 64); err == nil {
+		return []float64{n}, nil
+	}
+	var fields []string
+	var field strings.Builder
+	flush := func() {
+		if field.Len() > 0 {
+			fields = append(fields, field.String())
+			field.Reset()
+		}
+	}
+	for _, r := range s {
+		switch {
+		case r == '-' && field.Len() == 0:
+			field.WriteRune(r)
+		case r == '-' || r == ' ' || r == '\t' || r == ',' || r == '/' || r == '.':
+			flush()
+		default:
+			field.WriteRune(r)
+		}
+	}
+	flush()
+	if len(fields) == 0 {
+		return nil, errors.New("empty date")
+	}
+	components := make([]float64, len(fields))
+	for i, f := range fields {
+		n, err := strconv.ParseFloat(f, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid component %q", f)
+		}
+		components[i] = n
+	}
+	return components, nil
+}
+
+// writer writes converted dates and holidays in an output format.
+type writer interface {
+	dates(input string, absoluteDate float64, dates []lc.Date)
+	holidays(holidays []lc.Holiday)
+	flush()
+}
+
+// newWriter returns a writer for a given output format.
+func newWriter(format string, w io.Writer) (writer, error) {
+	switch format {
+	case "text":
+		return &textWriter{w: w}, nil
+	case "json":
+		return &jsonWriter{w: w}, nil
+	case "csv":
+		return &csvWriter{w: csv.NewWriter(w)}, nil
+	default:
+		return nil, fmt.Errorf("unknown format %q", format)
+	}
+}
+
+// textWriter writes aligned, human-readable text.
+type textWriter struct {
+	w     io.Writer
+	count int
+}
+
+func (t *textWriter) dates(input string, absoluteDate float64, dates []lc.Date) {
+	if t.count > 0 {
+		fmt.Fprintln(t.w)
+	}
+	t.count++
+	tw := tabwriter.NewWriter(t.w, 0, 8, 2, ' ', 0)
+	fmt.Fprintf(tw, "absolute\t%v\n", absoluteDate)
+	for _, d := range dates {
+		fmt.Fprintf(tw, "%s\t%s\n", d.Calendar, d)
+	}
+	tw.Flush()
+}
+
+func (t *textWriter) holidays(holidays []lc.Holiday) {
+	tw := tabwriter.NewWriter(t.w, 0, 8, 2, ' ', 0)
+	for _, h := range holidays {
+		fmt.Fprintf(tw, "%s\t%s\n", lc.GregorianFromAbsolute(h.Date), h.Name)
+	}
+	tw.Flush()
+}
+
+func (t *textWriter) flush() {}
+
+// jsonWriter writes one JSON object per line, using the Date JSON format.
+type jsonWriter struct {
+	w io.Writer
+}
+
+func (j *jsonWriter) dates(input string, absoluteDate float64, dates []lc.Date) {
+	elems := make([]string, len(dates))
+	for i, d := range dates {
+		elems[i] = d.Json()
+	}
+	fmt.Fprintf(j.w, "{\"input\":%s,\"absolute\":%v,\"dates\":[%s]}\n",
+		strconv.Quote(input), absoluteDate, strings.Join(elems, ","))
+}
+
+func (j *jsonWriter) holidays(holidays []lc.Holiday) {
+	for _, h := range holidays {
+		fmt.Fprintf(j.w, "{\"name\":%s,\"absolute\":%v,\"date\":%s}\n",
+			strconv.Quote(h.Name), h.Date, lc.GregorianFromAbsolute(h.Date).Date().Json())
+	}
+}
+
+func (j *jsonWriter) flush() {}
+
+// csvWriter writes comma-separated values with a header row.
+type csvWriter struct {
+	w      *csv.Writer
+	header bool
+}
+
+func (c *csvWriter) dates(input string, absoluteDate float64, dates []lc.Date) {
+	if !c.header {
+		c.w.Write([]string{"input", "absolute", "calendar", "components", "date"})
+		c.header = true
+	}
+	for _, d := range dates {
+		components := make([]string, len(d.Components))
+		for i, x := range d.Components {
+			components[i] = fmt.Sprint(x)
+		}
+		c.w.Write([]string{input, fmt.Sprint(absoluteDate), d.Calendar, strings.Join(components, " "), d.String()})
+	}
+}
+
+func (c *csvWriter) holidays(holidays []lc.Holiday) {
+	c.w.Write([]string{"name", "absolute", "date"})
+	for _, h := range holidays {
+		c.w.Write([]string{h.Name, fmt.Sprint(h.Date), lc.GregorianFromAbsolute(h.Date).String()})
+	}
+}
+
+func (c *csvWriter) flush() { c.w.Flush() }
@@ -0,0 +1,94 @@
+// Copyright (C) 2022  Alexander Staudt
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	var tests = []struct {
+		args   []string
+		stdin  string
+		status int
+		want   []string
+	}{
+		{[]string{"-to", "gregorian,hebrew", "2022-6-15"}, "", 0, []string{"absolute   738321", "gregorian  15 June 2022", "hebrew     16 Sivan 5782"}},
+		{[]string{"-from", "hebrew", "-to", "gregorian", "5782", "9", "25"}, "", 0, []string{"gregorian  29 November 2021"}},
+		{[]string{"-from", "absolute", "-to", "mayanLongCount", "738321"}, "", 0, []string{"mayanLongCount  13.0.9.11.3"}},
+		{[]string{"-from", "julianDay", "-to", "gregorian,modifiedJulianDay", "2459745.5"}, "", 0, []string{"absolute           738321", "gregorian          15 June 2022", "modifiedJulianDay  MJD 59745"}},
+		{[]string{"-from", "mayanLongCount", "-to", "gregorian", "13.0.9.11.3"}, "", 0, []string{"gregorian  15 June 2022"}},
+		{[]string{"-to", "islamic", "-format", "json", "2022/6/15"}, "", 0, []string{`{"input":"2022/6/15","absolute":738321,"dates":[{"calendar":"islamic","components":[1443,11,15]`}},
+		{[]string{"-to", "julian", "-format", "csv", "2022.6.15"}, "", 0, []string{"input,absolute,calendar,components,date", "2022.6.15,738321,julian,2022 6 2,2 June 2022"}},
+		{[]string{"-to", "gregorian", "-from", "julian"}, "2022-6-2\n\n# comment\n1582 10 5\n", 0, []string{"gregorian  15 June 2022", "gregorian  15 October 1582"}},
+		{[]string{"-holidays", "2022"}, "", 0, []string{"25 December 2022   Christmas", "17 April 2022      Easter"}},
+		{[]string{"-holidays", "2022", "-format", "csv"}, "", 0, []string{"name,absolute,date", "Christmas,738514,25 December 2022"}},
+		{[]string{"-calendars"}, "", 0, []string{"gregorian\n", "oldHinduLunar\n"}},
+		{[]string{"-grid", "month", "-to", "hebrew", "-secondary", "gregorian", "-mark-holidays", "2022-6-15"}, "", 0, []string{"     Sivan 5782\n31 May 2022 - 29 June 2022\n", " 6* 7  8", " 6  Pentecost"}},
+		{[]string{"-grid", "year", "-from", "french", "230-1-1"}, "", 0, []string{"Vendémiaire 230", "Sansculottides 230"}},
+		{[]string{"-grid", "year", "-to", "bahai", "2022-6-15"}, "", 0, []string{"Bahá 179", "Ayyám-i-Há 179", "ʻAláʼ 179"}},
+		{[]string{"-grid", "month", "-to", "mayanHaab", "2022-6-15"}, "", 1, nil},
+		{[]string{"-grid", "week", "2022-6-15"}, "", 2, nil},
+		{[]string{"-from", "hebrew", "5782-9"}, "", 1, nil},
+		{[]string{"-to", "aztec", "2022-6-15"}, "", 2, nil},
+		{[]string{"-format", "xml", "2022-6-15"}, "", 2, nil},
+		{[]string{"2022-June-15"}, "", 1, nil},
+	}
+	for _, tt := range tests {
+		testname := fmt.Sprint(tt.args)
+		t.Run(testname, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			status := run(tt.args, strings.NewReader(tt.stdin), &stdout, &stderr)
+			t.Logf("got %v, want %v", status, tt.status)
+			if status != tt.status {
+				t.Errorf("got %v, want %v (stderr: %s)", status, tt.status, stderr.String())
+			}
+			for _, w := range tt.want {
+				if !strings.Contains(stdout.String(), w) {
+					t.Errorf("output %q does not contain %q", stdout.String(), w)
+				}
+			}
+		})
+	}
+}
+
+func TestParseComponents(t *testing.T) {
+	var tests = []struct {
+		input string
+		want  []float64
+	}{
+		{"2022-6-15", []float64{2022, 6, 15}},
+		{"-3760 7 1", []float64{-3760, 7, 1}},
+		{"-44/3/-15", []float64{-44, 3, -15}},
+		{"13.0.9.11.3", []float64{13, 0, 9, 11, 3}},
+		{" 5782, 9, 25 ", []float64{5782, 9, 25}},
+		{"2459745.5", []float64{2459745.5}},
+		{"-1.25", []float64{-1.25}},
+	}
+	for _, tt := range tests {
+		testname := tt.input
+		t.Run(testname, func(t *testing.T) {
+			ans, err := parseComponents(tt.input)
+			t.Logf("got %v, want %v", ans, tt.want)
+			if err != nil || fmt.Sprint(ans) != fmt.Sprint(tt.want) {
+				t.Errorf("got %v (%v), want %v", ans, err, tt.want)
+			}
+		})
+	}
+}
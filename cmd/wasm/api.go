@@ -0,0 +1,97 @@
+// Copyright (C) 2022  Alexander Staudt
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Command wasm exposes libcalendar to JavaScript. Built with GOOS=js
+// GOARCH=wasm, it registers a global object "libcalendar" whose functions
+// mirror the library's:
+//
+//	libcalendar.calendars()                        // ["gregorian", ...]
+//	libcalendar.fromAbsolute(738321, "hebrew")     // "16 Sivan 5782"
+//	libcalendar.jsonDateFromAbsolute(738321, "hebrew")
+//	libcalendar.absoluteFromDate('{"calendar":"hebrew","components":[5782,3,16]}')
+//	libcalendar.holidaysInGregorianYear(2022)
+//	libcalendar.holidaysInRange(738300, 738400)
+//
+// Dates are passed and returned in the Date JSON format of libcalendar;
+// absoluteFromDate also accepts a Date object. Functions called with
+// arguments of the wrong type return null.
+//
+// Build and test:
+//
+//	GOOS=js GOARCH=wasm go build -o libcalendar.wasm ./cmd/wasm
+//	GOOS=js GOARCH=wasm go test -exec="$(go env GOROOT)/lib/wasm/go_js_wasm_exec" ./cmd/wasm
+package main
+
+import (
+	"encoding/json"
+	"math"
+
+	lc "staudtlex.de/libcalendar"
+)
+
+// holiday is the JSON representation of a libcalendar Holiday.
+type holiday struct {
+	Name     string          `json:"name"`
+	Absolute float64         `json:"absolute"`
+	Date     json.RawMessage `json:"date"`
+}
+
+// calendars returns the names of the supported calendars.
+func calendars() []string {
+	return lc.Calendars()
+}
+
+// fromAbsolute returns a string-formatted date of a given calendar.
+func fromAbsolute(absoluteDate float64, calendar string) string {
+	return lc.FromAbsolute(absoluteDate, calendar)
+}
+
+// jsonDateFromAbsolute returns a JSON-serialized Date of a given calendar.
+func jsonDateFromAbsolute(absoluteDate float64, calendar string) string {
+	return lc.JsonDateFromAbsolute(absoluteDate, calendar)
+}
+
+// absoluteFromDate returns the absolute date of a JSON-serialized Date, or
+// NaN if the Date cannot be converted.
+func absoluteFromDate(date string) float64 {
+	d := lc.JsonToDate(date)
+	d = lc.DateFromComponents(d.Calendar, d.Components)
+	if d.Calendar == "" {
+		return math.NaN()
+	}
+	return lc.AbsoluteFromDate(d)
+}
+
+// jsonHolidays returns holidays as a JSON array, with their Gregorian dates
+// in the Date JSON format.
+func jsonHolidays(holidays []lc.Holiday) string {
+	result := make([]holiday, 0, len(holidays))
+	for _, h := range holidays {
+		result = append(result, holiday{h.Name, h.Date, json.RawMessage(lc.JsonDateFromAbsolute(h.Date, "gregorian"))})
+	}
+	s, _ := json.Marshal(result)
+	return string(s)
+}
+
+// holidaysInGregorianYear returns the holidays of a Gregorian year as JSON.
+func holidaysInGregorianYear(year float64) string {
+	return jsonHolidays(lc.HolidaysInGregorianYear(year))
+}
+
+// holidaysInRange returns the holidays from absolute date `from` through `to`
+// as JSON.
+func holidaysInRange(from, to float64) string {
+	return jsonHolidays(lc.HolidaysInRange(from, to))
+}
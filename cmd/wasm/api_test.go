@@ -0,0 +1,74 @@
+// Copyright (C) 2022  Alexander Staudt
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestAbsoluteFromDate(t *testing.T) {
+	var tests = []struct {
+		date string
+		want float64
+	}{
+		{`{"calendar":"gregorian","components":[2022,6,15]}`, 738321},
+		{`{"calendar":"hebrew","components":[5782,3,16],"componentNames":["year","month","day"]}`, 738321},
+		{`{"components":[13,0,9,11,3],"calendar":"mayanLongCount"}`, 738321},
+		{`{"calendar":"gregorian","components":[2022,6]}`, math.NaN()},
+		{`{"calendar":"aztec","components":[2022,6,15]}`, math.NaN()},
+		{`{"calendar":"mayanHaab","components":[16,4]}`, math.NaN()},
+		{`{"note":"calendar"}`, math.NaN()},
+		{`{"calendar":{}}`, math.NaN()},
+		{`{"calendar":"gregorian","components":[2022,6,15`, math.NaN()},
+		{`[`, math.NaN()},
+		{``, math.NaN()},
+	}
+	for _, tt := range tests {
+		testname := tt.date
+		t.Run(testname, func(t *testing.T) {
+			ans := absoluteFromDate(tt.date)
+			t.Logf("got %v, want %v", ans, tt.want)
+			if fmt.Sprint(ans) != fmt.Sprint(tt.want) {
+				t.Errorf("got %v, want %v", ans, tt.want)
+			}
+		})
+	}
+}
+
+func TestConversions(t *testing.T) {
+	var tests = []struct {
+		ans  string
+		want string
+	}{
+		{fromAbsolute(738321, "hebrew"), "16 Sivan 5782"},
+		{jsonDateFromAbsolute(738321, "gregorian"), `{"calendar":"gregorian","components":[2022,6,15],`},
+		{holidaysInGregorianYear(2022), `{"name":"Christmas","absolute":738514,"date":{"calendar":"gregorian","components":[2022,12,25],`},
+		{holidaysInRange(738310, 738320), `[{"name":"Pentecost","absolute":738311,`},
+		{holidaysInRange(738320, 738300), `[]`},
+	}
+	for _, tt := range tests {
+		testname := tt.want
+		t.Run(testname, func(t *testing.T) {
+			t.Logf("got %v, want %v", tt.ans, tt.want)
+			if !strings.Contains(tt.ans, tt.want) {
+				t.Errorf("got %v, want %v", tt.ans, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright (C) 2022  Alexander Staudt
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+//go:build js && wasm
+
+package main
+
+import "syscall/js"
+
+// number returns the float64 value of a JavaScript number.
+func number(v js.Value) (float64, bool) {
+	if v.Type() != js.TypeNumber {
+		return 0, false
+	}
+	return v.Float(), true
+}
+
+// text returns the value of a JavaScript string. Objects are serialized with
+// JSON.stringify.
+func text(v js.Value) (string, bool) {
+	switch v.Type() {
+	case js.TypeString:
+		return v.String(), true
+	case js.TypeObject:
+		return js.Global().Get("JSON").Call("stringify", v).String(), true
+	default:
+		return "", false
+	}
+}
+
+// exports returns the functions registered in the global object
+// "libcalendar".
+func exports() map[string]any {
+	return map[string]any{
+		"calendars": js.FuncOf(func(this js.Value, args []js.Value) any {
+			names := []any{}
+			for _, c := range calendars() {
+				names = append(names, c)
+			}
+			return names
+		}),
+		"fromAbsolute": js.FuncOf(func(this js.Value, args []js.Value) any {
+			if len(args) < 2 {
+				return nil
+			}
+			rd, ok := number(args[0])
+			if !ok || args[1].Type() != js.TypeString {
+				return nil
+			}
+			return fromAbsolute(rd, args[1].String())
+		}),
+		"jsonDateFromAbsolute": js.FuncOf(func(this js.Value, args []js.Value) any {
+			if len(args) < 2 {
+				return nil
+			}
+			rd, ok := number(args[0])
+			if !ok || args[1].Type() != js.TypeString {
+				return nil
+			}
+			return jsonDateFromAbsolute(rd, args[1].String())
+		}),
+		"absoluteFromDate": js.FuncOf(func(this js.Value, args []js.Value) any {
+			if len(args) < 1 {
+				return nil
+			}
+			date, ok := text(args[0])
+			if !ok {
+				return nil
+			}
+			return absoluteFromDate(date)
+		}),
+		"holidaysInGregorianYear": js.FuncOf(func(this js.Value, args []js.Value) any {
+			if len(args) < 1 {
+				return nil
+			}
+			year, ok := number(args[0])
+			if !ok {
+				return nil
+			}
+			return holidaysInGregorianYear(year)
+		}),
+		"holidaysInRange": js.FuncOf(func(this js.Value, args []js.Value) any {
+			if len(args) < 2 {
+				return nil
+			}
+			from, ok1 := number(args[0])
+			to, ok2 := number(args[1])
+			if !ok1 || !ok2 {
+				return nil
+			}
+			return holidaysInRange(from, to)
+		}),
+	}
+}
+
+func main() {
+	js.Global().Set("libcalendar", js.ValueOf(exports()))
+	// keep the Go program alive to serve calls from JavaScript
+	select {}
+}
@@ -0,0 +1,69 @@
+// Copyright (C) 2022  Alexander Staudt
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+//go:build js && wasm
+
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"syscall/js"
+	"testing"
+
+	lc "staudtlex.de/libcalendar"
+)
+
+// TestExports calls the exported functions from JavaScript's side, as a
+// front end would.
+func TestExports(t *testing.T) {
+	js.Global().Set("libcalendar", js.ValueOf(exports()))
+	libcalendar := js.Global().Get("libcalendar")
+	date := js.Global().Get("JSON").Call("parse", `{"calendar":"hebrew","components":[5782,3,16]}`)
+
+	var tests = []struct {
+		ans  js.Value
+		want string
+	}{
+		{libcalendar.Call("fromAbsolute", 738321, "islamic"), "15 Dhu al-Qada 1443"},
+		{libcalendar.Call("absoluteFromDate", `{"calendar":"gregorian","components":[2022,6,15]}`), "738321"},
+		{libcalendar.Call("absoluteFromDate", date), "738321"},
+		{libcalendar.Call("absoluteFromDate", 738321), "null"},
+		{libcalendar.Call("absoluteFromDate", `{"note":"calendar"}`), "NaN"},
+		{libcalendar.Call("absoluteFromDate", `{"calendar":{}}`), "NaN"},
+		{libcalendar.Call("fromAbsolute", "738321", "islamic"), "null"},
+		{libcalendar.Call("calendars").Index(0), "gregorian"},
+		{libcalendar.Call("calendars").Get("length"), strconv.Itoa(len(lc.Calendars()))},
+		{js.Global().Get("JSON").Call("parse", libcalendar.Call("jsonDateFromAbsolute", 738321, "hebrew")).Get("weekday"), "Yom Revi'i"},
+		{js.Global().Get("JSON").Call("parse", libcalendar.Call("holidaysInGregorianYear", 2022)).Index(0).Get("name"), "Epiphany"},
+		{js.Global().Get("JSON").Call("parse", libcalendar.Call("holidaysInRange", 738310, 738320)).Get("length"), "1"},
+	}
+	for i, tt := range tests {
+		testname := fmt.Sprint(i)
+		t.Run(testname, func(t *testing.T) {
+			ans := tt.ans.String()
+			switch tt.ans.Type() {
+			case js.TypeNumber:
+				ans = fmt.Sprint(tt.ans.Float())
+			case js.TypeNull:
+				ans = "null"
+			}
+			t.Logf("got %v, want %v", ans, tt.want)
+			if ans != tt.want {
+				t.Errorf("got %v, want %v", ans, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright (C) 2022  Alexander Staudt
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// This file implements conversions between absolute dates and the day counts
+// used by astronomers, operating systems and spreadsheets. Absolute dates may
+// be fractional, the fraction denoting the time of day elapsed since midnight.
+
+package libcalendar
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// Epochs of the day counts, i.e. the absolute dates of their day 0
+const (
+	julianDayEpoch         = -1721424.5 // noon, 1 January 4713 B.C.E. (Julian)
+	modifiedJulianDayEpoch = 678576     // 17 November 1858 (Gregorian)
+	unixDayEpoch           = 719163     // 1 January 1970 (Gregorian)
+	lilianDayEpoch         = 577735     // 14 October 1582 (Gregorian)
+	excelSerialEpoch       = 693594     // 30 December 1899 (Gregorian)
+	excelLeapDay           = 693655     // 1 March 1900, following Excel's fictitious 29 February 1900
+)
+
+// JulianDayFromAbsolute returns the (astronomical) Julian day number of a
+// given absolute date. Julian days begin at noon, so the Julian day of
+// midnight is a half-integer.
+func JulianDayFromAbsolute(absoluteDate float64) float64 {
+	return absoluteDate - julianDayEpoch
+}
+
+// AbsoluteFromJulianDay returns the absolute date of a given Julian day
+// number.
+func AbsoluteFromJulianDay(jd float64) float64 {
+	return jd + julianDayEpoch
+}
+
+// ModifiedJulianDayFromAbsolute returns the Modified Julian Day (JD -
+// 2400000.5) of a given absolute date.
+func ModifiedJulianDayFromAbsolute(absoluteDate float64) float64 {
+	return absoluteDate - modifiedJulianDayEpoch
+}
+
+// AbsoluteFromModifiedJulianDay returns the absolute date of a given Modified
+// Julian Day.
+func AbsoluteFromModifiedJulianDay(mjd float64) float64 {
+	return mjd + modifiedJulianDayEpoch
+}
+
+// UnixDayFromAbsolute returns the number of days since 1 January 1970 of a
+// given absolute date, i.e. Unix time divided by 86400.
+func UnixDayFromAbsolute(absoluteDate float64) float64 {
+	return absoluteDate - unixDayEpoch
+}
+
+// AbsoluteFromUnixDay returns the absolute date of a given number of days
+// since 1 January 1970.
+func AbsoluteFromUnixDay(day float64) float64 {
+	return day + unixDayEpoch
+}
+
+// LilianDayFromAbsolute returns the Lilian day number of a given absolute
+// date, counting 15 October 1582 (Gregorian) as day 1.
+func LilianDayFromAbsolute(absoluteDate float64) float64 {
+	return absoluteDate - lilianDayEpoch
+}
+
+// AbsoluteFromLilianDay returns the absolute date of a given Lilian day
+// number.
+func AbsoluteFromLilianDay(day float64) float64 {
+	return day + lilianDayEpoch
+}
+
+// ExcelSerialFromAbsolute returns the serial date of a given absolute date in
+// the 1900 date system of spreadsheets, which counts 1 January 1900 as day 1
+// and includes the non-existent 29 February 1900 (day 60).
+func ExcelSerialFromAbsolute(absoluteDate float64) float64 {
+	if absoluteDate < excelLeapDay {
+		return absoluteDate - excelSerialEpoch - 1
+	}
+	return absoluteDate - excelSerialEpoch
+}
+
+// AbsoluteFromExcelSerial returns the absolute date of a given serial date of
+// the 1900 date system, or NaN for day 60 (29 February 1900).
+func AbsoluteFromExcelSerial(serial float64) float64 {
+	switch {
+	case math.Floor(serial) == 60:
+		return math.NaN()
+	case serial < 60:
+		return serial + excelSerialEpoch + 1
+	default:
+		return serial + excelSerialEpoch
+	}
+}
+
+// A dayCount describes a day count usable as a calendar by FromAbsolute,
+// DateFromAbsolute and AbsoluteFromDate.
+type dayCount struct {
+	label        string
+	fromAbsolute func(float64) float64
+	toAbsolute   func(float64) float64
+}
+
+// Supported day counts by calendar name
+var dayCounts = map[string]dayCount{
+	"julianDay":         {"JD", JulianDayFromAbsolute, AbsoluteFromJulianDay},
+	"modifiedJulianDay": {"MJD", ModifiedJulianDayFromAbsolute, AbsoluteFromModifiedJulianDay},
+	"unixDay":           {"Unix day", UnixDayFromAbsolute, AbsoluteFromUnixDay},
+	"excelSerial":       {"Excel serial", ExcelSerialFromAbsolute, AbsoluteFromExcelSerial},
+	"lilianDay":         {"Lilian day", LilianDayFromAbsolute, AbsoluteFromLilianDay},
+	"rataDie":           {"R.D.", func(x float64) float64 { return x }, func(x float64) float64 { return x }},
+}
+
+// dayCountString formats a day count, e.g. "JD 2459745.5".
+func dayCountString(calendar string, day float64) string {
+	return fmt.Sprintf("%s %s", dayCounts[calendar].label, formatFloat(day))
+}
+
+// formatFloat formats a number without exponent, as needed for large day
+// counts with fractions.
+func formatFloat(x float64) string {
+	return strconv.FormatFloat(x, 'f', -1, 64)
+}
+
+// dayCountDate creates a Date of a day count from a given absolute date.
+func dayCountDate(absoluteDate float64, calendar string) Date {
+	return Date{
+		Calendar:       calendar,
+		Components:     []float64{dayCounts[calendar].fromAbsolute(absoluteDate)},
+		ComponentNames: []string{"day"},
+		MonthNames:     []string{},
+		Weekday:        DayOfWeek(math.Floor(absoluteDate)).String(),
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright (C) 2022  Alexander Staudt
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package libcalendar
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestDayCounts(t *testing.T) {
+	var tests = []struct {
+		calendar     string
+		absoluteDate float64
+		want         float64
+	}{
+		{"julianDay", 738321, 2459745.5},
+		{"julianDay", 738321.75, 2459746.25},
+		{"julianDay", 678576, 2400000.5},
+		{"julianDay", 1, 1721425.5},
+		{"modifiedJulianDay", 738321, 59745},
+		{"modifiedJulianDay", 738321.5, 59745.5},
+		{"unixDay", 719163, 0},
+		{"unixDay", 738321, 19158},
+		{"excelSerial", 738321, 44727},
+		{"excelSerial", 738321.25, 44727.25},
+		{"excelSerial", 693596, 1},  // 1 January 1900
+		{"excelSerial", 693654, 59}, // 28 February 1900
+		{"excelSerial", 693655, 61}, // 1 March 1900
+		{"lilianDay", 577736, 1},
+		{"lilianDay", 738321, 160586},
+		{"rataDie", 738321, 738321},
+	}
+	for _, tt := range tests {
+		testname := fmt.Sprintf("%s %v", tt.calendar, tt.absoluteDate)
+		t.Run(testname, func(t *testing.T) {
+			d := DateFromAbsolute(tt.absoluteDate, tt.calendar)
+			ans := AbsoluteFromDate(d)
+			t.Logf("got %v, want %v", d.Components, tt.want)
+			if len(d.Components) != 1 || d.Components[0] != tt.want || ans != tt.absoluteDate {
+				t.Errorf("got %v (%v), want %v (%v)", d.Components, ans, tt.want, tt.absoluteDate)
+			}
+		})
+	}
+
+	t.Run("excelSerial 60", func(t *testing.T) {
+		ans := AbsoluteFromExcelSerial(60)
+		t.Logf("got %v, want NaN", ans)
+		if !math.IsNaN(ans) || ValidateDate(Date{Calendar: "excelSerial", Components: []float64{60}}) == nil {
+			t.Errorf("got %v, want NaN", ans)
+		}
+	})
+}
+
+func TestDayCountStrings(t *testing.T) {
+	var tests = []struct {
+		calendar string
+		want     string
+		json     string
+	}{
+		{"julianDay", "JD 2459745.5", `{"calendar":"julianDay","components":[2459745.5],"componentNames":["day"],"monthNames":[],"weekday":"Wednesday"}`},
+		{"modifiedJulianDay", "MJD 59745", `{"calendar":"modifiedJulianDay","components":[59745],"componentNames":["day"],"monthNames":[],"weekday":"Wednesday"}`},
+		{"unixDay", "Unix day 19158", `{"calendar":"unixDay","components":[19158],"componentNames":["day"],"monthNames":[],"weekday":"Wednesday"}`},
+		{"rataDie", "R.D. 738321", `{"calendar":"rataDie","components":[738321],"componentNames":["day"],"monthNames":[],"weekday":"Wednesday"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.calendar, func(t *testing.T) {
+			d := DateFromAbsolute(738321, tt.calendar)
+			s, json := FromAbsolute(738321, tt.calendar), d.Json()
+			t.Logf("got %v, want %v", s, tt.want)
+			if s != tt.want || d.String() != tt.want || json != tt.json {
+				t.Errorf("got %v, %v, want %v, %v", s, json, tt.want, tt.json)
+			}
+			if back := JsonToDate(json); fmt.Sprint(back.Components) != fmt.Sprint(d.Components) || back.Calendar != tt.calendar {
+				t.Errorf("got %v, want %v", back, d)
+			}
+		})
+	}
+}
+
+func TestJsonToDateNumbers(t *testing.T) {
+	var tests = []struct {
+		json string
+		want []float64
+	}{
+		{`{"calendar":"mayanLongCount","components":[-1,0,0,0,0]}`, []float64{-1, 0, 0, 0, 0}},
+		{`{"calendar":"julianDay","components":[2459745.25]}`, []float64{2459745.25}},
+		{`{"calendar":"julianDay","components":2.4597455e+06}`, []float64{2459745.5}},
+		{`{"calendar":"gregorian","components":[ -44, 3, 15 ]}`, []float64{-44, 3, 15}},
+		{`{"calendar":"gregorian","components":[2022, 6`, nil},
+		{`{"note":"calendar"}`, []float64{}},
+		{`{"calendar":{}}`, []float64{}},
+		{`{"components"`, []float64{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.json, func(t *testing.T) {
+			ans := JsonToDate(tt.json).Components
+			t.Logf("got %v, want %v", ans, tt.want)
+			if fmt.Sprint(ans) != fmt.Sprint(tt.want) {
+				t.Errorf("got %v, want %v", ans, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright (C) 2022  Alexander Staudt
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// This file implements the Egyptian and Armenian calendars, "wandering"
+// calendars of 365 days without leap years: twelve months of 30 days are
+// followed by five epagomenal days, which are treated as a thirteenth month,
+// as are the sansculottides of the French Revolutionary calendar (see
+// Reingold/Dershowitz 2018).
+
+package libcalendar
+
+import "math"
+
+// Egyptian date type. Month 13 denotes the epagomenal days.
+type EgyptianDate struct {
+	Year  float64
+	Month float64 // 1 to 13
+	Day   float64 // 1 to 30, or 1 to 5 in month 13
+}
+
+// Armenian date type. Month 13 denotes the epagomenal days.
+type ArmenianDate struct {
+	Year  float64
+	Month float64 // 1 to 13
+	Day   float64 // 1 to 30, or 1 to 5 in month 13
+}
+
+// EgyptianEpoch is the absolute date of 1 Thoth 1 of the era of Nabonassar
+// (26 February 747 B.C.E., Julian).
+const EgyptianEpoch float64 = -272787
+
+// ArmenianEpoch is the absolute date of 1 Nawasardi 1 (11 July 552 C.E.,
+// Julian).
+const ArmenianEpoch float64 = 201443
+
+// absoluteFromWandering returns the absolute date of a given date of a
+// 365-day calendar beginning at epoch.
+func absoluteFromWandering(year, month, day, epoch float64) float64 {
+	return epoch + 365*(year-1) + 30*(month-1) + day - 1
+}
+
+// wanderingFromAbsolute returns the year, month and day of a given absolute
+// date in a 365-day calendar beginning at epoch.
+func wanderingFromAbsolute(absoluteDate, epoch float64) (year, month, day float64) {
+	days := absoluteDate - epoch
+	year = math.Floor(days/365) + 1
+	month = math.Floor(mod(days, 365)/30) + 1
+	day = days - 365*(year-1) - 30*(month-1) + 1
+	return year, month, day
+}
+
+// EgyptianCalendar converts between absolute dates and Egyptian dates whose
+// year 1 begins on a given epoch, e.g. to count years from the accession of a
+// king. The zero value uses EgyptianEpoch, as do the Egyptian calendar
+// functions of this package; NewEgyptianCalendar sets any other epoch,
+// including R.D. 0.
+type EgyptianCalendar struct {
+	shift float64 // days from EgyptianEpoch to the beginning of year 1
+}
+
+// NewEgyptianCalendar returns an EgyptianCalendar whose year 1 begins on a
+// given absolute date.
+func NewEgyptianCalendar(epoch float64) EgyptianCalendar {
+	return EgyptianCalendar{epoch - EgyptianEpoch}
+}
+
+// Epoch returns the absolute date of the beginning of year 1.
+func (c EgyptianCalendar) Epoch() float64 {
+	return EgyptianEpoch + c.shift
+}
+
+// FromAbsolute returns the Egyptian date of a given absolute date.
+func (c EgyptianCalendar) FromAbsolute(absoluteDate float64) EgyptianDate {
+	year, month, day := wanderingFromAbsolute(absoluteDate, c.Epoch())
+	return EgyptianDate{year, month, day}
+}
+
+// Absolute returns the absolute date of a given Egyptian date.
+func (c EgyptianCalendar) Absolute(d EgyptianDate) (absoluteDate float64) {
+	return absoluteFromWandering(d.Year, d.Month, d.Day, c.Epoch())
+}
+
+// ArmenianCalendar converts between absolute dates and Armenian dates whose
+// year 1 begins on a given epoch. The zero value uses ArmenianEpoch, as do the
+// Armenian calendar functions of this package; NewArmenianCalendar sets any
+// other epoch, including R.D. 0.
+type ArmenianCalendar struct {
+	shift float64 // days from ArmenianEpoch to the beginning of year 1
+}
+
+// NewArmenianCalendar returns an ArmenianCalendar whose year 1 begins on a
+// given absolute date.
+func NewArmenianCalendar(epoch float64) ArmenianCalendar {
+	return ArmenianCalendar{epoch - ArmenianEpoch}
+}
+
+// Epoch returns the absolute date of the beginning of year 1.
+func (c ArmenianCalendar) Epoch() float64 {
+	return ArmenianEpoch + c.shift
+}
+
+// FromAbsolute returns the Armenian date of a given absolute date.
+func (c ArmenianCalendar) FromAbsolute(absoluteDate float64) ArmenianDate {
+	year, month, day := wanderingFromAbsolute(absoluteDate, c.Epoch())
+	return ArmenianDate{year, month, day}
+}
+
+// Absolute returns the absolute date of a given Armenian date.
+func (c ArmenianCalendar) Absolute(d ArmenianDate) (absoluteDate float64) {
+	return absoluteFromWandering(d.Year, d.Month, d.Day, c.Epoch())
+}
+
+// AbsoluteFromEgyptian computes the absolute (fixed) date from a given
+// Egyptian date.
+func AbsoluteFromEgyptian(d EgyptianDate) (absoluteDate float64) {
+	return EgyptianCalendar{}.Absolute(d)
+}
+
+// EgyptianFromAbsolute computes the Egyptian date from a given absolute
+// (fixed) date.
+func EgyptianFromAbsolute(absoluteDate float64) EgyptianDate {
+	return EgyptianCalendar{}.FromAbsolute(absoluteDate)
+}
+
+// AbsoluteFromArmenian computes the absolute (fixed) date from a given
+// Armenian date.
+func AbsoluteFromArmenian(d ArmenianDate) (absoluteDate float64) {
+	return ArmenianCalendar{}.Absolute(d)
+}
+
+// ArmenianFromAbsolute computes the Armenian date from a given absolute
+// (fixed) date.
+func ArmenianFromAbsolute(absoluteDate float64) ArmenianDate {
+	return ArmenianCalendar{}.FromAbsolute(absoluteDate)
+}
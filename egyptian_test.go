@@ -0,0 +1,87 @@
+// Copyright (C) 2022  Alexander Staudt
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package libcalendar
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestEgyptianArmenian(t *testing.T) {
+	var tests = []struct {
+		rd       float64
+		egyptian EgyptianDate
+		armenian ArmenianDate
+	}{
+		{-214193, EgyptianDate{161, 7, 15}, ArmenianDate{-1138, 4, 10}},
+		{-61387, EgyptianDate{580, 3, 6}, ArmenianDate{-720, 12, 6}},
+		{25469, EgyptianDate{818, 2, 22}, ArmenianDate{-482, 11, 22}},
+		{49217, EgyptianDate{883, 3, 15}, ArmenianDate{-417, 12, 15}},
+		{171307, EgyptianDate{1217, 9, 15}, ArmenianDate{-82, 6, 10}},
+		{210155, EgyptianDate{1324, 2, 18}, ArmenianDate{24, 11, 18}},
+		{253427, EgyptianDate{1442, 9, 10}, ArmenianDate{143, 6, 5}},
+		{EgyptianEpoch + 364, EgyptianDate{1, 13, 5}, ArmenianDate{-1298, 9, 30}},
+	}
+	for _, tt := range tests {
+		testname := fmt.Sprintf("%.0f", tt.rd)
+		t.Run(testname, func(t *testing.T) {
+			egyptian, armenian := EgyptianFromAbsolute(tt.rd), ArmenianFromAbsolute(tt.rd)
+			t.Logf("got %v %v, want %v %v", egyptian, armenian, tt.egyptian, tt.armenian)
+			if egyptian != tt.egyptian || armenian != tt.armenian {
+				t.Errorf("got %v %v, want %v %v", egyptian, armenian, tt.egyptian, tt.armenian)
+			}
+			if d := AbsoluteFromEgyptian(tt.egyptian); d != tt.rd {
+				t.Errorf("got %v, want %v", d, tt.rd)
+			}
+			if d := AbsoluteFromArmenian(tt.armenian); d != tt.rd {
+				t.Errorf("got %v, want %v", d, tt.rd)
+			}
+		})
+	}
+
+	t.Run("epoch", func(t *testing.T) {
+		// years of Philip Arrhidaeus, beginning 12 November 324 B.C.E.
+		philip := NewEgyptianCalendar(EgyptianEpoch + 365*424)
+		rd := AbsoluteFromJulian(JulianDate{-323, 11, 12})
+		if got, want := philip.FromAbsolute(rd), (EgyptianDate{1, 1, 1}); got != want {
+			t.Errorf("got %v, want %v", got, want)
+		}
+		if got := philip.Absolute(EgyptianDate{1, 1, 1}); got != rd {
+			t.Errorf("got %v, want %v", got, rd)
+		}
+		if got := (ArmenianCalendar{}).Absolute(ArmenianDate{1, 1, 1}); got != ArmenianEpoch {
+			t.Errorf("got %v, want %v", got, ArmenianEpoch)
+		}
+		// R.D. 0 is an epoch like any other
+		if got := NewArmenianCalendar(0).Absolute(ArmenianDate{1, 1, 1}); got != 0 {
+			t.Errorf("got %v, want %v", got, 0)
+		}
+		if got, want := NewEgyptianCalendar(0).FromAbsolute(0), (EgyptianDate{1, 1, 1}); got != want {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, calendar := range []string{"egyptian", "armenian"} {
+			if err := ValidateDate(DateFromComponents(calendar, []float64{1, 13, 6})); err != ErrInvalidDate {
+				t.Errorf("got %v, want %v", err, ErrInvalidDate)
+			}
+			if err := ValidateDate(DateFromComponents(calendar, []float64{1, 13, 5})); err != nil {
+				t.Errorf("got %v, want %v", err, nil)
+			}
+		}
+	})
+}
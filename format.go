@@ -115,6 +115,7 @@ var mayanHaabMonths = map[float64]string{
 	16: "Pax",
 	17: "Kayab",
 	18: "Cumku",
+	19: "Uayeb",
 }
 
 var mayanTzolkinNames = map[float64]string{
@@ -152,6 +153,60 @@ func (d MayanTzolkinDate) String() string {
 	return fmt.Sprintf("%v %v", d.Number, mayanTzolkinNames[d.Name])
 }
 
+// Aztec calendars
+var aztecXihuitlMonths = map[float64]string{
+	1:  "Izcalli",
+	2:  "Atlcahualo",
+	3:  "Tlacaxipehualiztli",
+	4:  "Tozoztontli",
+	5:  "Huei Tozoztli",
+	6:  "Toxcatl",
+	7:  "Etzalcualiztli",
+	8:  "Tecuilhuitontli",
+	9:  "Huei Tecuilhuitl",
+	10: "Tlaxochimaco",
+	11: "Xocotlhuetzi",
+	12: "Ochpaniztli",
+	13: "Teotleco",
+	14: "Tepeilhuitl",
+	15: "Quecholli",
+	16: "Panquetzaliztli",
+	17: "Atemoztli",
+	18: "Tititl",
+	19: "Nemontemi",
+}
+
+var aztecTonalpohualliNames = map[float64]string{
+	1:  "Cipactli",
+	2:  "Ehecatl",
+	3:  "Calli",
+	4:  "Cuetzpallin",
+	5:  "Coatl",
+	6:  "Miquiztli",
+	7:  "Mazatl",
+	8:  "Tochtli",
+	9:  "Atl",
+	10: "Itzcuintli",
+	11: "Ozomatli",
+	12: "Malinalli",
+	13: "Acatl",
+	14: "Ocelotl",
+	15: "Quauhtli",
+	16: "Cozcaquauhtli",
+	17: "Ollin",
+	18: "Tecpatl",
+	19: "Quiahuitl",
+	20: "Xochitl",
+}
+
+func (d AztecXihuitlDate) String() string {
+	return fmt.Sprintf("%v %v", d.Day, aztecXihuitlMonths[d.Month])
+}
+
+func (d AztecTonalpohualliDate) String() string {
+	return fmt.Sprintf("%v %v", d.Number, aztecTonalpohualliNames[d.Name])
+}
+
 // French Revolutionary calendar
 var frenchMonths = map[float64]string{
 	1:  "Vendémiaire",
@@ -173,6 +228,102 @@ func (d FrenchDate) String() string {
 	return fmt.Sprintf("%v %v an %v", d.Day, frenchMonths[d.Month], d.Year)
 }
 
+// Egyptian and Armenian calendars
+var egyptianMonths = map[float64]string{
+	1:  "Thoth",
+	2:  "Phaophi",
+	3:  "Athyr",
+	4:  "Choiak",
+	5:  "Tybi",
+	6:  "Mechir",
+	7:  "Phamenoth",
+	8:  "Pharmuthi",
+	9:  "Pachon",
+	10: "Payni",
+	11: "Epiphi",
+	12: "Mesori",
+	13: "Epagomenae",
+}
+
+var armenianMonths = map[float64]string{
+	1:  "Nawasardi",
+	2:  "Hoṙi",
+	3:  "Sahmi",
+	4:  "Trē",
+	5:  "Kʻałocʻ",
+	6:  "Aracʻ",
+	7:  "Mehekani",
+	8:  "Areg",
+	9:  "Ahekani",
+	10: "Mareri",
+	11: "Margacʻ",
+	12: "Hroticʻ",
+	13: "Aweleacʻ",
+}
+
+func (d EgyptianDate) String() string {
+	return fmt.Sprintf("%v %v %v", d.Day, egyptianMonths[d.Month], d.Year)
+}
+
+func (d ArmenianDate) String() string {
+	return fmt.Sprintf("%v %v %v", d.Day, armenianMonths[d.Month], d.Year)
+}
+
+// Roman calendar
+var romanMonths = map[float64]string{
+	1:  "Ianuarius",
+	2:  "Februarius",
+	3:  "Martius",
+	4:  "Aprilis",
+	5:  "Maius",
+	6:  "Iunius",
+	7:  "Iulius",
+	8:  "Augustus",
+	9:  "September",
+	10: "October",
+	11: "November",
+	12: "December",
+}
+
+// Month names in the ablative (Kalendis Martiis) and accusative (ante diem
+// III Nonas Martias) plural
+var (
+	romanMonthsAblative = map[float64]string{
+		1: "Ianuariis", 2: "Februariis", 3: "Martiis", 4: "Aprilibus",
+		5: "Maiis", 6: "Iuniis", 7: "Iuliis", 8: "Augustis",
+		9: "Septembribus", 10: "Octobribus", 11: "Novembribus", 12: "Decembribus",
+	}
+	romanMonthsAccusative = map[float64]string{
+		1: "Ianuarias", 2: "Februarias", 3: "Martias", 4: "Apriles",
+		5: "Maias", 6: "Iunias", 7: "Iulias", 8: "Augustas",
+		9: "Septembres", 10: "Octobres", 11: "Novembres", 12: "Decembres",
+	}
+	romanEventsAblative   = map[float64]string{RomanKalends: "Kalendis", RomanNones: "Nonis", RomanIdes: "Idibus"}
+	romanEventsAccusative = map[float64]string{RomanKalends: "Kalendas", RomanNones: "Nonas", RomanIdes: "Idus"}
+)
+
+// Day returns the Latin name of the day of its receiver, e.g. "Idibus
+// Martiis", "pridie Nonas Martias" or "ante diem bis VI Kalendas Martias".
+func (d RomanDate) Day() string {
+	switch {
+	case d.Count == 1:
+		return fmt.Sprintf("%v %v", romanEventsAblative[d.Event], romanMonthsAblative[d.Month])
+	case d.Count == 2:
+		return fmt.Sprintf("pridie %v %v", romanEventsAccusative[d.Event], romanMonthsAccusative[d.Month])
+	}
+	count := FormatNumeral(d.Count, RomanNumerals)
+	if d.Leap {
+		count = "bis " + count
+	}
+	return fmt.Sprintf("ante diem %v %v %v", count, romanEventsAccusative[d.Event], romanMonthsAccusative[d.Month])
+}
+
+// String returns the Latin name of the day and the year A.U.C. of its
+// receiver, e.g. "Idibus Martiis 710 a.u.c.".
+func (d RomanDate) String() string {
+	return fmt.Sprintf("%v %v a.u.c.", d.Day(), d.AUC())
+}
+
 // Old Hindu calendars
 var hinduSolarMonths = map[float64]string{
 	1:  "Mesha",
@@ -211,3 +362,151 @@ var hinduLunarMonths = map[float64]string{
 func (d OldHinduLunarDate) String() string {
 	return fmt.Sprintf("%v %v %v", d.Day, hinduLunarMonths[d.Month], d.Year)
 }
+
+// Modern Hindu calendars, sharing the month names of the Old Hindu calendars
+func (d HinduSolarDate) String() string {
+	return fmt.Sprintf("%v %v %v", d.Day, hinduSolarMonths[d.Month], d.Year)
+}
+
+func (d HinduLunarDate) String() string {
+	day, month := fmt.Sprint(d.Day), hinduLunarMonths[d.Month]
+	if d.LeapDay {
+		day += " (adhika)"
+	}
+	if d.LeapMonth {
+		month = "Adhika " + month
+	}
+	return fmt.Sprintf("%v %v %v", day, month, d.Year)
+}
+
+// Bahá'í calendar
+var bahaiMonths = map[float64]string{
+	0:  "Ayyám-i-Há",
+	1:  "Bahá",
+	2:  "Jalál",
+	3:  "Jamál",
+	4:  "ʻAẓamat",
+	5:  "Núr",
+	6:  "Raḥmat",
+	7:  "Kalimát",
+	8:  "Kamál",
+	9:  "Asmáʼ",
+	10: "ʻIzzat",
+	11: "Mashíyyat",
+	12: "ʻIlm",
+	13: "Qudrat",
+	14: "Qawl",
+	15: "Masáʼil",
+	16: "Sharaf",
+	17: "Sulṭán",
+	18: "Mulk",
+	19: "ʻAláʼ",
+}
+
+func (d BahaiDate) String() string {
+	return fmt.Sprintf("%v %v %v", d.Day, bahaiMonths[d.Month], d.BahaiYear())
+}
+
+// Panchang elements
+var tithis = map[float64]string{
+	1: "Pratipada", 2: "Dvitiya", 3: "Tritiya", 4: "Chaturthi", 5: "Panchami",
+	6: "Shashthi", 7: "Saptami", 8: "Ashtami", 9: "Navami", 10: "Dashami",
+	11: "Ekadashi", 12: "Dvadashi", 13: "Trayodashi", 14: "Chaturdashi", 15: "Purnima",
+}
+
+var nakshatras = map[float64]string{
+	1: "Ashvini", 2: "Bharani", 3: "Krittika", 4: "Rohini", 5: "Mrigashira",
+	6: "Ardra", 7: "Punarvasu", 8: "Pushya", 9: "Ashlesha", 10: "Magha",
+	11: "Purva Phalguni", 12: "Uttara Phalguni", 13: "Hasta", 14: "Chitra",
+	15: "Svati", 16: "Vishakha", 17: "Anuradha", 18: "Jyeshtha", 19: "Mula",
+	20: "Purva Ashadha", 21: "Uttara Ashadha", 22: "Shravana", 23: "Dhanishtha",
+	24: "Shatabhisha", 25: "Purva Bhadrapada", 26: "Uttara Bhadrapada", 27: "Revati",
+}
+
+var yogas = map[float64]string{
+	1: "Vishkambha", 2: "Priti", 3: "Ayushman", 4: "Saubhagya", 5: "Shobhana",
+	6: "Atiganda", 7: "Sukarman", 8: "Dhriti", 9: "Shula", 10: "Ganda",
+	11: "Vriddhi", 12: "Dhruva", 13: "Vyaghata", 14: "Harshana", 15: "Vajra",
+	16: "Siddhi", 17: "Vyatipata", 18: "Variyan", 19: "Parigha", 20: "Shiva",
+	21: "Siddha", 22: "Sadhya", 23: "Shubha", 24: "Shukla", 25: "Brahma",
+	26: "Indra", 27: "Vaidhriti",
+}
+
+var karanas = map[float64]string{
+	0: "Kimstughna", 1: "Bava", 2: "Balava", 3: "Kaulava", 4: "Taitila",
+	5: "Gara", 6: "Vanija", 7: "Vishti", 8: "Shakuni", 9: "Chatushpada", 10: "Naga",
+}
+
+// TithiName returns the name of a lunar day, e.g. "Shukla Pratipada" for the
+// first day of the waxing moon, or "Amavasya" for the new moon.
+func TithiName(tithi float64) string {
+	switch {
+	case tithi == 30:
+		return "Amavasya"
+	case tithi == 15:
+		return tithis[15]
+	case tithi > 15:
+		return "Krishna " + tithis[tithi-15]
+	default:
+		return "Shukla " + tithis[tithi]
+	}
+}
+
+func (p Panchang) String() string {
+	return fmt.Sprintf("%v, %v, %v, %v, %v", TithiName(p.Tithi), hinduWeekdays[float64(p.Vara)],
+		nakshatras[p.Nakshatra], yogas[p.Yoga], karanas[p.Karana])
+}
+
+// Balinese Pawukon calendar
+var baliDwiwara = map[float64]string{1: "Menga", 2: "Pepet"}
+
+var baliTriwara = map[float64]string{1: "Pasah", 2: "Beteng", 3: "Kajeng"}
+
+var baliCaturwara = map[float64]string{1: "Sri", 2: "Laba", 3: "Jaya", 4: "Menala"}
+
+var baliPancawara = map[float64]string{1: "Umanis", 2: "Paing", 3: "Pon", 4: "Wage", 5: "Keliwon"}
+
+var baliSadwara = map[float64]string{1: "Tungleh", 2: "Aryang", 3: "Urukung", 4: "Paniron", 5: "Was", 6: "Maulu"}
+
+var baliSaptawara = map[float64]string{
+	1: "Redite", 2: "Coma", 3: "Anggara", 4: "Buda", 5: "Wraspati", 6: "Sukra", 7: "Saniscara",
+}
+
+var baliAsatawara = map[float64]string{
+	1: "Sri", 2: "Indra", 3: "Guru", 4: "Yama", 5: "Ludra", 6: "Brahma", 7: "Kala", 8: "Uma",
+}
+
+var baliSangawara = map[float64]string{
+	1: "Dangu", 2: "Jangur", 3: "Gigis", 4: "Nohan", 5: "Ogan", 6: "Erangan", 7: "Urungan", 8: "Tulus", 9: "Dadi",
+}
+
+var baliDasawara = map[float64]string{
+	0: "Pandita", 1: "Pati", 2: "Suka", 3: "Duka", 4: "Sri", 5: "Manuh", 6: "Manusa", 7: "Raja", 8: "Dewa", 9: "Raksasa",
+}
+
+var baliWeeks = map[float64]string{
+	1: "Sinta", 2: "Landep", 3: "Ukir", 4: "Kulantir", 5: "Tolu", 6: "Gumbreg",
+	7: "Wariga", 8: "Warigadean", 9: "Julungwangi", 10: "Sungsang", 11: "Dunggulan", 12: "Kuningan",
+	13: "Langkir", 14: "Medangsia", 15: "Pujut", 16: "Pahang", 17: "Krulut", 18: "Merakih",
+	19: "Tambir", 20: "Medangkungan", 21: "Matal", 22: "Uye", 23: "Menail", 24: "Prangbakat",
+	25: "Bala", 26: "Ugu", 27: "Wayang", 28: "Klawu", 29: "Dukut", 30: "Watugunung",
+}
+
+// Names returns the names of the days of the ten weeks of its receiver, from
+// the 1-day to the 10-day week. The day of the 1-day week is named Luang, or
+// is empty.
+func (d BaliPawukonDate) Names() []string {
+	luang := ""
+	if d.Luang {
+		luang = "Luang"
+	}
+	return []string{luang, baliDwiwara[d.Dwiwara], baliTriwara[d.Triwara], baliCaturwara[d.Caturwara],
+		baliPancawara[d.Pancawara], baliSadwara[d.Sadwara], baliSaptawara[d.Saptawara],
+		baliAsatawara[d.Asatawara], baliSangawara[d.Sangawara], baliDasawara[d.Dasawara]}
+}
+
+// String returns the names of the days of the 7- and 5-day weeks and the
+// name of the week (wuku), e.g. "Buda Keliwon Dunggulan".
+func (d BaliPawukonDate) String() string {
+	return fmt.Sprintf("%v %v %v", baliSaptawara[d.Saptawara], baliPancawara[d.Pancawara], baliWeeks[d.Week()])
+}
@@ -1,3 +1,3 @@
 module staudtlex.de/libcalendar
 
-go 1.17
+go 1.23
@@ -0,0 +1,337 @@
+// Copyright (C) 2022  Alexander Staudt
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// This file implements cal(1)-style plain-text month and year grids.
+
+package libcalendar
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"unicode/utf8"
+)
+
+// GridOptions control how month grids are built.
+type GridOptions struct {
+	Locale       string  // locale of the month names, defaults to RootLocale
+	FirstWeekday Weekday // first column of seven-day weeks (ISO weeks start on Monday)
+	Secondary    string  // calendar whose day numbers annotate each day, if not empty
+	Holidays     bool    // mark the holidays listed by HolidaysInGregorianYear
+}
+
+// GridCell is a day of a month grid. The cells before the first and after
+// the last day of the month are zero (Day == 0).
+type GridCell struct {
+	AbsoluteDate float64
+	Day          float64
+	SecondaryDay float64  // day of the month in the secondary calendar
+	Holidays     []string // names of the holidays on this day
+}
+
+// Grid is a month laid out in rows of weeks or, for the French Revolutionary
+// calendar, of décades.
+type Grid struct {
+	Calendar    string
+	Title       string       // month name and year
+	Subtitle    string       // first and last day in the secondary calendar
+	Columns     []string     // column headers
+	Rows        [][]GridCell // rows of len(Columns) cells
+	WeekNumbers []float64    // ISO week numbers of the rows (calendar "iso")
+}
+
+// Column headers
+var (
+	weekdayColumns = []string{"Su", "Mo", "Tu", "We", "Th", "Fr", "Sa"}
+	decadeColumns  = []string{"Pr", "Du", "Tr", "Qa", "Qi", "Sx", "Sp", "Oc", "No", "Dé"}
+)
+
+// gridCalendar returns the calendar whose months and days a grid of a given
+// calendar shows. ISO grids show Gregorian months.
+func gridCalendar(calendar string) (string, bool) {
+	switch calendar {
+	case "gregorian", "julian", "islamic", "hebrew", "french",
+		"oldHinduSolar", "oldHinduLunar", "hinduSolar", "hinduLunar", "bahai", "westernBahai":
+		return calendar, true
+	case "iso":
+		return "gregorian", true
+	default:
+		return "", false
+	}
+}
+
+// gridDay returns the day of the month of an absolute date.
+func gridDay(absoluteDate float64, calendar string) float64 {
+	day, _, _, _ := DateFromAbsolute(absoluteDate, calendar).dayMonthYear()
+	return day
+}
+
+// monthBounds returns the first and last absolute date of the month
+// containing a given absolute date.
+func monthBounds(absoluteDate float64, calendar string) (first, last float64) {
+	month := calendarPeriod(absoluteDate, calendar, false)
+	for first = absoluteDate; calendarPeriod(first-1, calendar, false) == month; first-- {
+	}
+	for last = absoluteDate; calendarPeriod(last+1, calendar, false) == month; last++ {
+	}
+	return first, last
+}
+
+// gridTitle returns the month name and year of a month beginning on a given
+// absolute date.
+func gridTitle(first float64, calendar string, tag string) string {
+	var month, year float64
+	leap := false
+	switch calendar {
+	case "oldHinduLunar":
+		d := OldHinduLunarFromAbsolute(first)
+		month, year, leap = d.Month, d.Year, d.LeapMonth
+	case "hinduLunar":
+		d := HinduLunarFromAbsolute(first)
+		month, year, leap = d.Month, d.Year, d.LeapMonth
+	default:
+		_, month, year, _ = DateFromAbsolute(first, calendar).dayMonthYear()
+	}
+	name := LocalizedMonthName(calendar, month, year, tag)
+	if leap {
+		name = "Adhika " + name
+	}
+	return fmt.Sprintf("%v %v", name, year)
+}
+
+// MonthGrid returns the grid of the month containing a given absolute date.
+// Hebrew, Islamic, Hindu, Gregorian and Julian months are laid out in
+// seven-day weeks, ISO grids show the Gregorian month in ISO weeks (starting
+// on Monday) with week numbers, and French Revolutionary months are laid out
+// in décades of ten days.
+func MonthGrid(calendar string, absoluteDate float64, opts GridOptions) (Grid, error) {
+	cal, ok := gridCalendar(calendar)
+	if !ok || math.IsNaN(absoluteDate) {
+		return Grid{}, fmt.Errorf("libcalendar: no month grid for calendar %q", calendar)
+	}
+	secondary, ok := gridCalendar(opts.Secondary)
+	if opts.Secondary != "" && !ok {
+		return Grid{}, fmt.Errorf("libcalendar: no day numbers for calendar %q", opts.Secondary)
+	}
+	if opts.FirstWeekday < Sunday || opts.FirstWeekday > Saturday {
+		return Grid{}, fmt.Errorf("libcalendar: invalid first weekday %d", opts.FirstWeekday)
+	}
+	if opts.Locale == "" {
+		opts.Locale = RootLocale
+	}
+
+	first, last := monthBounds(absoluteDate, cal)
+	g := Grid{Calendar: calendar, Title: gridTitle(first, cal, opts.Locale)}
+	if secondary != "" {
+		g.Subtitle = DateFromAbsolute(first, secondary).String() + " - " +
+			DateFromAbsolute(last, secondary).String()
+	}
+
+	// column of a given absolute date
+	column := func(d float64) int {
+		return int(mod(float64(DayOfWeek(d)-opts.FirstWeekday), 7))
+	}
+	switch {
+	case calendar == "french":
+		g.Columns = append([]string(nil), decadeColumns...)
+		column = func(d float64) int {
+			f := FrenchFromAbsolute(d)
+			if f.Month == 13 {
+				return int(f.Day) - 1
+			}
+			return int(f.DayOfDecade()) - 1
+		}
+	case calendar == "iso":
+		opts.FirstWeekday = Monday
+		fallthrough
+	default:
+		g.Columns = make([]string, 0, len(weekdayColumns))
+		g.Columns = append(g.Columns, weekdayColumns[opts.FirstWeekday:]...)
+		g.Columns = append(g.Columns, weekdayColumns[:opts.FirstWeekday]...)
+	}
+
+	holidays := map[float64][]string{}
+	if opts.Holidays {
+		for _, h := range HolidaysInRange(first, last) {
+			holidays[h.Date] = append(holidays[h.Date], h.Name)
+		}
+	}
+
+	var row []GridCell
+	for d := first; d <= last; d++ {
+		c := column(d)
+		if row == nil || c == 0 {
+			if row != nil {
+				g.Rows = append(g.Rows, row)
+			}
+			row = make([]GridCell, len(g.Columns))
+			if calendar == "iso" {
+				g.WeekNumbers = append(g.WeekNumbers, IsoFromAbsolute(d).Week)
+			}
+		}
+		row[c] = GridCell{AbsoluteDate: d, Day: gridDay(d, cal), Holidays: holidays[d]}
+		if secondary != "" {
+			row[c].SecondaryDay = gridDay(d, secondary)
+		}
+	}
+	g.Rows = append(g.Rows, row)
+	return g, nil
+}
+
+// YearGrid returns the grids of the months of a given year. Hebrew years
+// begin with Tishri, ISO years are shown as the months of the Gregorian year.
+func YearGrid(calendar string, year float64, opts GridOptions) ([]Grid, error) {
+	cal, ok := gridCalendar(calendar)
+	if !ok {
+		return nil, fmt.Errorf("libcalendar: no month grid for calendar %q", calendar)
+	}
+	var starts []float64
+	switch cal {
+	case "hebrew":
+		for d := range HebrewMonths(year) {
+			starts = append(starts, AbsoluteFromHebrew(d))
+		}
+	case "oldHinduLunar":
+		for d := range OldHinduLunarMonths(year) {
+			starts = append(starts, AbsoluteFromOldHinduLunar(d))
+		}
+	default:
+		first := AbsoluteFromDate(DateFromComponents(cal, []float64{year, 1, 1}))
+		next := AbsoluteFromDate(DateFromComponents(cal, []float64{year + 1, 1, 1}))
+		for d := range MonthStarts(first, next-1, cal) {
+			starts = append(starts, d)
+		}
+	}
+	grids := make([]Grid, 0, len(starts))
+	for _, d := range starts {
+		g, err := MonthGrid(calendar, d, opts)
+		if err != nil {
+			return nil, err
+		}
+		grids = append(grids, g)
+	}
+	return grids, nil
+}
+
+// center pads a string with blanks to center it within a given width.
+func center(s string, width int) string {
+	n := utf8.RuneCountInString(s)
+	if n >= width {
+		return s
+	}
+	return strings.Repeat(" ", (width-n)/2) + s
+}
+
+// String renders its receiver as plain text. Holidays are marked with an
+// asterisk and listed below the grid; secondary day numbers are printed
+// below the days.
+func (g Grid) String() string {
+	var b strings.Builder
+	prefix := ""
+	if g.WeekNumbers != nil {
+		prefix = "    "
+	}
+	width := len(prefix) + 3*len(g.Columns) - 1
+	line := func(s string) {
+		b.WriteString(strings.TrimRight(s, " "))
+		b.WriteByte('\n')
+	}
+
+	line(center(g.Title, width))
+	if g.Subtitle != "" {
+		line(center(g.Subtitle, width))
+	}
+	if prefix != "" {
+		prefix = "Wk  "
+	}
+	line(prefix + strings.Join(g.Columns, " "))
+	var legend []string
+	for i, row := range g.Rows {
+		var days, secondary strings.Builder
+		if g.WeekNumbers != nil {
+			fmt.Fprintf(&days, "%2v  ", g.WeekNumbers[i])
+			secondary.WriteString("    ")
+		}
+		for _, c := range row {
+			if c.Day == 0 {
+				days.WriteString("   ")
+				secondary.WriteString("   ")
+				continue
+			}
+			marker := " "
+			if len(c.Holidays) > 0 {
+				marker = "*"
+				legend = append(legend, fmt.Sprintf("%2v  %s", c.Day, strings.Join(c.Holidays, ", ")))
+			}
+			fmt.Fprintf(&days, "%2v%s", c.Day, marker)
+			if c.SecondaryDay != 0 {
+				fmt.Fprintf(&secondary, "%2v ", c.SecondaryDay)
+			} else {
+				secondary.WriteString("   ")
+			}
+		}
+		line(days.String())
+		if g.Subtitle != "" {
+			line(secondary.String())
+		}
+	}
+	for _, l := range legend {
+		line(l)
+	}
+	return b.String()
+}
+
+// JoinGrids renders grids side by side, a given number per row, like the
+// year view of cal(1).
+func JoinGrids(grids []Grid, perRow int) string {
+	if perRow < 1 {
+		perRow = 1
+	}
+	var b strings.Builder
+	for i := 0; i < len(grids); i += perRow {
+		blocks := [][]string{}
+		widths := []int{}
+		height := 0
+		for _, g := range grids[i:min(i+perRow, len(grids))] {
+			lines := strings.Split(strings.TrimRight(g.String(), "\n"), "\n")
+			width := 0
+			for _, l := range lines {
+				width = max(width, utf8.RuneCountInString(l))
+			}
+			blocks = append(blocks, lines)
+			widths = append(widths, width)
+			height = max(height, len(lines))
+		}
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		for l := 0; l < height; l++ {
+			var row strings.Builder
+			for j, lines := range blocks {
+				s := ""
+				if l < len(lines) {
+					s = lines[l]
+				}
+				if j > 0 {
+					row.WriteString("   ")
+				}
+				row.WriteString(s + strings.Repeat(" ", widths[j]-utf8.RuneCountInString(s)))
+			}
+			b.WriteString(strings.TrimRight(row.String(), " "))
+			b.WriteByte('\n')
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,158 @@
+// Copyright (C) 2022  Alexander Staudt
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package libcalendar
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestMonthGrid(t *testing.T) {
+	var tests = []struct {
+		calendar string
+		date     float64
+		title    string
+		columns  int
+		rows     int
+		first    [2]float64 // column and day of the first cell of the first row
+	}{
+		{"hebrew", 738321, "Sivan 5782", 7, 5, [2]float64{2, 1}},
+		{"islamic", 738321, "Dhu al-Qada 1443", 7, 5, [2]float64{3, 1}},
+		{"iso", 738321, "June 2022", 7, 5, [2]float64{2, 1}},
+		{"french", 738321, "Prairial 230", 10, 3, [2]float64{0, 1}},
+		{"french", AbsoluteFromFrench(FrenchDate{230, 13, 3}), "Sansculottides 230", 10, 1, [2]float64{0, 1}},
+		{"oldHinduSolar", 738321, "Vrshabha 5123", 7, 5, [2]float64{2, 1}},
+		{"oldHinduLunar", 738321, "Jyaishtha 5123", 7, 5, [2]float64{2, 1}},
+	}
+	for _, tt := range tests {
+		testname := fmt.Sprintf("%v %v", tt.calendar, tt.date)
+		t.Run(testname, func(t *testing.T) {
+			g, err := MonthGrid(tt.calendar, tt.date, GridOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			column := 0
+			for g.Rows[0][column].Day == 0 {
+				column++
+			}
+			ans := fmt.Sprint(g.Title, len(g.Columns), len(g.Rows), column, g.Rows[0][column].Day)
+			want := fmt.Sprint(tt.title, tt.columns, tt.rows, tt.first[0], tt.first[1])
+			t.Logf("got %v, want %v", ans, want)
+			if ans != want {
+				t.Errorf("got %v, want %v", ans, want)
+			}
+		})
+	}
+}
+
+func TestMonthGridString(t *testing.T) {
+	g, err := MonthGrid("hebrew", 738321, GridOptions{Secondary: "gregorian", Holidays: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `     Sivan 5782
+31 May 2022 - 29 June 2022
+Su Mo Tu We Th Fr Sa
+       1  2  3  4  5
+      31  1  2  3  4
+ 6* 7  8  9 10 11 12
+ 5  6  7  8  9 10 11
+13 14 15 16 17 18 19
+12 13 14 15 16 17 18
+20 21 22 23 24 25 26
+19 20 21 22 23 24 25
+27 28 29 30
+26 27 28 29
+ 6  Pentecost
+`
+	ans := g.String()
+	t.Logf("got\n%v", ans)
+	if ans != want {
+		t.Errorf("got\n%v\nwant\n%v", ans, want)
+	}
+
+	g, _ = MonthGrid("iso", 738321, GridOptions{})
+	want = `       June 2022
+Wk  Mo Tu We Th Fr Sa Su
+22         1  2  3  4  5
+23   6  7  8  9 10 11 12
+24  13 14 15 16 17 18 19
+25  20 21 22 23 24 25 26
+26  27 28 29 30
+`
+	ans = g.String()
+	t.Logf("got\n%v", ans)
+	if ans != want {
+		t.Errorf("got\n%v\nwant\n%v", ans, want)
+	}
+}
+
+func TestMonthGridColumns(t *testing.T) {
+	g, err := MonthGrid("gregorian", 738321, GridOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	g.Columns[0] = "XX"
+	g, _ = MonthGrid("gregorian", 738321, GridOptions{FirstWeekday: Monday})
+	ans := strings.Join(g.Columns, " ")
+	want := "Mo Tu We Th Fr Sa Su"
+	t.Logf("got %v, want %v", ans, want)
+	if ans != want {
+		t.Errorf("got %v, want %v", ans, want)
+	}
+	g, _ = MonthGrid("gregorian", 738321, GridOptions{})
+	ans = strings.Join(g.Columns, " ")
+	want = "Su Mo Tu We Th Fr Sa"
+	t.Logf("got %v, want %v", ans, want)
+	if ans != want {
+		t.Errorf("got %v, want %v", ans, want)
+	}
+}
+
+func TestYearGrid(t *testing.T) {
+	var tests = []struct {
+		calendar string
+		year     float64
+		want     int
+	}{
+		{"hebrew", 5782, 13},
+		{"hebrew", 5783, 12},
+		{"islamic", 1443, 12},
+		{"french", 230, 13},
+		{"iso", 2022, 12},
+		{"oldHinduLunar", 5123, 12},
+	}
+	for _, tt := range tests {
+		testname := fmt.Sprintf("%v %v", tt.calendar, tt.year)
+		t.Run(testname, func(t *testing.T) {
+			grids, err := YearGrid(tt.calendar, tt.year, GridOptions{})
+			t.Logf("got %v, want %v", len(grids), tt.want)
+			if err != nil || len(grids) != tt.want {
+				t.Errorf("got %v (%v), want %v", len(grids), err, tt.want)
+			}
+		})
+	}
+	if _, err := YearGrid("mayanHaab", 1, GridOptions{}); err == nil {
+		t.Errorf("got no error for calendar mayanHaab")
+	}
+	if _, err := MonthGrid("hebrew", 738321, GridOptions{FirstWeekday: 7}); err == nil {
+		t.Errorf("got no error for first weekday 7")
+	}
+	if _, err := MonthGrid("hebrew", 738321, GridOptions{Secondary: "mayanTzolkin"}); err == nil {
+		t.Errorf("got no error for secondary calendar mayanTzolkin")
+	}
+}
@@ -0,0 +1,360 @@
+// Copyright (C) 2022  Alexander Staudt
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// The following Go code implements the modern Hindu solar and lunisolar
+// calendars after the Surya Siddhanta, as discussed in:
+// - Reingold, Edward, and Nachum Dershowitz. 2018. Calendrical Calculations:
+//   The Ultimate Edition. 4th edition. Cambridge: Cambridge University Press.
+//
+// Unlike the Old Hindu calendars, which use the mean positions of sun and
+// moon, these use their true positions, obtained by epicycles computed with
+// the traditional sine table, and reckon days from true sunrise at Ujjain.
+
+package libcalendar
+
+import "math"
+
+// Modern Hindu dates
+type HinduSolarDate struct {
+	Year  float64 // Saka era
+	Month float64 // sign of the zodiac, 1 (Mesha) to 12 (Mina)
+	Day   float64
+}
+
+type HinduLunarDate struct {
+	Year      float64 // Vikrama era
+	Month     float64 // 1 (Chaitra) to 12 (Phalguna), months beginning at new moon
+	LeapMonth bool    // intercalated month (adhika masa)
+	Day       float64 // tithi, 1 to 30
+	LeapDay   bool    // second day with the same tithi (adhika tithi)
+}
+
+// Constants of the Surya Siddhanta
+const (
+	hinduSiderealYear      = 365 + 279457.0/1080000
+	hinduAnomalisticYear   = 1577917828000.0 / (4320000000 - 387)
+	hinduSiderealMonth     = 27 + 4644439.0/14438334
+	hinduSynodicMonth      = 29 + 7087771.0/13358334
+	hinduAnomalisticMonth  = 1577917828.0 / (57753336 - 488199)
+	hinduCreation          = oldHinduEpoch - 1955880000*hinduSiderealYear
+	hinduSolarEra          = 3179 // years from the Kali Yuga to the Saka era
+	hinduLunarEra          = 3044 // years from the Kali Yuga to the Vikrama era
+	hinduSineTableInterval = 225.0 / 60
+)
+
+// Ujjain, the prime meridian of Hindu astronomy
+var ujjain = Location{Latitude: 23 + 9.0/60, Longitude: 75 + 46.0/60 + 6.0/3600, Elevation: 0, Zone: 5 + 461.0/9000}
+
+// mod3 returns x shifted into the interval [a, b).
+func mod3(x, a, b float64) float64 {
+	if a == b {
+		return x
+	}
+	return a + mod(x-a, b-a)
+}
+
+// hinduSineTable returns the traditional sine table entry for
+// entry * 225 arc minutes, i.e. the sine rounded to 3438ths (the radius in
+// arc minutes), with the systematic errors of the historical table.
+func hinduSineTable(entry float64) float64 {
+	exact := 3438 * math.Sin(radians(entry*hinduSineTableInterval))
+	sign := func(x float64) float64 {
+		switch {
+		case x > 0:
+			return 1
+		case x < 0:
+			return -1
+		default:
+			return 0
+		}
+	}
+	errorTerm := 0.215 * sign(exact) * sign(math.Abs(exact)-1716)
+	return math.Round(exact+errorTerm) / 3438
+}
+
+// hinduSine interpolates the sine table linearly.
+func hinduSine(theta float64) float64 {
+	entry := theta / hinduSineTableInterval
+	fraction := mod(entry, 1)
+	return fraction*hinduSineTable(math.Ceil(entry)) + (1-fraction)*hinduSineTable(math.Floor(entry))
+}
+
+// hinduArcsin inverts hinduSine.
+func hinduArcsin(amp float64) float64 {
+	if amp < 0 {
+		return -hinduArcsin(-amp)
+	}
+	pos := 0.0
+	for amp > hinduSineTable(pos) {
+		pos++
+	}
+	below := hinduSineTable(pos - 1)
+	return hinduSineTableInterval * (pos - 1 + (amp-below)/(hinduSineTable(pos)-below))
+}
+
+// hinduMeanPosition returns the mean longitude (in degrees) at a given moment
+// of a body with a given period.
+func hinduMeanPosition(t Moment, period float64) float64 {
+	return 360 * mod((float64(t)-hinduCreation)/period, 1)
+}
+
+// hinduTruePosition returns the true longitude (in degrees) at a given moment
+// of a body with a given period, epicycle size, anomalistic period and change
+// of the epicycle.
+func hinduTruePosition(t Moment, period, size, anomalistic, change float64) float64 {
+	lambda := hinduMeanPosition(t, period)
+	offset := hinduSine(hinduMeanPosition(t, anomalistic))
+	contraction := math.Abs(offset) * change * size
+	equation := hinduArcsin(offset * (size - contraction))
+	return mod(lambda-equation, 360)
+}
+
+// HinduSolarLongitude returns the true sidereal longitude of the sun (in
+// degrees) at a given moment.
+func HinduSolarLongitude(t Moment) float64 {
+	return hinduTruePosition(t, hinduSiderealYear, 14.0/360, hinduAnomalisticYear, 1.0/42)
+}
+
+// HinduZodiac returns the sign of the zodiac (1 to 12) of the sun at a given
+// moment.
+func HinduZodiac(t Moment) float64 {
+	return math.Floor(HinduSolarLongitude(t)/30) + 1
+}
+
+// HinduLunarLongitude returns the true sidereal longitude of the moon (in
+// degrees) at a given moment.
+func HinduLunarLongitude(t Moment) float64 {
+	return hinduTruePosition(t, hinduSiderealMonth, 32.0/360, hinduAnomalisticMonth, 1.0/96)
+}
+
+// hinduLunarPhase returns the elongation of the moon from the sun (in
+// degrees) at a given moment.
+func hinduLunarPhase(t Moment) float64 {
+	return mod(HinduLunarLongitude(t)-HinduSolarLongitude(t), 360)
+}
+
+// hinduLunarDayFromMoment returns the lunar day (tithi, 1 to 30) in progress
+// at a given moment.
+func hinduLunarDayFromMoment(t Moment) float64 {
+	return math.Floor(hinduLunarPhase(t)/12) + 1
+}
+
+// HinduNewMoonBefore returns the moment of the last true new moon before a
+// given moment.
+func HinduNewMoonBefore(t Moment) Moment {
+	tau := t - Moment(hinduLunarPhase(t)/360*hinduSynodicMonth)
+	lo, hi := tau-1, min(t, tau+1)
+	for i := 0; i < 60 && HinduZodiac(lo) != HinduZodiac(hi); i++ {
+		x := (lo + hi) / 2
+		if hinduLunarPhase(x) < 180 {
+			hi = x
+		} else {
+			lo = x
+		}
+	}
+	return (lo + hi) / 2
+}
+
+// hinduCalendarYear returns the number of (solar) years elapsed since the
+// Kali Yuga at a given moment.
+func hinduCalendarYear(t Moment) float64 {
+	return math.Round((float64(t)-oldHinduEpoch)/hinduSiderealYear - HinduSolarLongitude(t)/360)
+}
+
+// hinduDailyMotion returns the daily motion of the sun (in degrees) on a
+// given absolute date.
+func hinduDailyMotion(absoluteDate float64) float64 {
+	meanMotion := 360 / hinduSiderealYear
+	anomaly := hinduMeanPosition(Moment(absoluteDate), hinduAnomalisticYear)
+	epicycle := 14.0/360 - math.Abs(hinduSine(anomaly))/1080
+	entry := math.Floor(anomaly / hinduSineTableInterval)
+	sineTableStep := hinduSineTable(entry+1) - hinduSineTable(entry)
+	factor := -3438.0 / 225 * sineTableStep * epicycle
+	return meanMotion * (factor + 1)
+}
+
+// hinduTropicalLongitude returns the tropical longitude of the sun (in
+// degrees) on a given absolute date, allowing for the precession of the
+// equinoxes.
+func hinduTropicalLongitude(absoluteDate float64) float64 {
+	days := absoluteDate - oldHinduEpoch
+	precession := 27 - math.Abs(108*mod3(600.0/1577917828*days-0.25, -0.5, 0.5))
+	return mod(HinduSolarLongitude(Moment(absoluteDate))-precession, 360)
+}
+
+// hinduRisingSign returns the tabulated speed of rising of the current
+// zodiacal sign on a given absolute date.
+func hinduRisingSign(absoluteDate float64) float64 {
+	i := mod(math.Floor(hinduTropicalLongitude(absoluteDate)/30), 6)
+	return []float64{1670.0 / 1800, 1795.0 / 1800, 1935.0 / 1800, 1935.0 / 1800, 1795.0 / 1800, 1670.0 / 1800}[int(i)]
+}
+
+// hinduSolarSiderealDifference returns the difference between solar and
+// sidereal day on a given absolute date.
+func hinduSolarSiderealDifference(absoluteDate float64) float64 {
+	return hinduDailyMotion(absoluteDate) * hinduRisingSign(absoluteDate)
+}
+
+// hinduEquationOfTime returns the time from true to mean midnight on a given
+// absolute date, as a fraction of a day.
+func hinduEquationOfTime(absoluteDate float64) float64 {
+	offset := hinduSine(hinduMeanPosition(Moment(absoluteDate), hinduAnomalisticYear))
+	equationSun := offset * (3438.0 / 60) * (14.0/360 - math.Abs(offset)/1080)
+	return hinduDailyMotion(absoluteDate) / 360 * equationSun / 360 * hinduSiderealYear
+}
+
+// hinduAscensionalDifference returns the difference between the times of
+// sunrise at a location and at the equator on a given absolute date (in
+// degrees).
+func hinduAscensionalDifference(absoluteDate float64, l Location) float64 {
+	sinDelta := 1397.0 / 3438 * hinduSine(hinduTropicalLongitude(absoluteDate))
+	phi := l.Latitude
+	diurnalRadius := hinduSine(90 + hinduArcsin(sinDelta))
+	tanPhi := hinduSine(phi) / hinduSine(90+phi)
+	earthSine := sinDelta * tanPhi
+	return hinduArcsin(-earthSine / diurnalRadius)
+}
+
+// HinduSunrise returns the moment of true sunrise at Ujjain on a given
+// absolute date, in local mean time of Ujjain, by which the modern Hindu
+// calendars reckon days.
+func HinduSunrise(absoluteDate float64) Moment {
+	return Moment(absoluteDate + meanSunrise - hinduEquationOfTime(absoluteDate) +
+		1577917828.0/1582237828/360*
+			(hinduAscensionalDifference(absoluteDate, ujjain)+
+				0.25*hinduSolarSiderealDifference(absoluteDate)))
+}
+
+// HinduSolarFromAbsolute returns the modern Hindu solar date corresponding to
+// a given absolute (fixed) date.
+func HinduSolarFromAbsolute(absoluteDate float64) HinduSolarDate {
+	critical := HinduSunrise(absoluteDate + 1)
+	month := HinduZodiac(critical)
+	year := hinduCalendarYear(critical) - hinduSolarEra
+	approx := absoluteDate - 3 - mod(math.Floor(HinduSolarLongitude(critical)), 30)
+	begin := approx
+	for HinduZodiac(HinduSunrise(begin+1)) != month {
+		begin++
+	}
+	return HinduSolarDate{year, month, absoluteDate - begin + 1}
+}
+
+// AbsoluteFromHinduSolar returns the absolute (fixed) date corresponding to a
+// given modern Hindu solar date.
+func AbsoluteFromHinduSolar(d HinduSolarDate) (absoluteDate float64) {
+	begin := math.Floor((d.Year+hinduSolarEra+(d.Month-1)/12)*hinduSiderealYear + oldHinduEpoch)
+	date := begin - 3
+	for HinduZodiac(HinduSunrise(date+1)) != d.Month {
+		date++
+	}
+	return date + d.Day - 1
+}
+
+// HinduLunarFromAbsolute returns the modern Hindu lunar date corresponding to
+// a given absolute (fixed) date.
+func HinduLunarFromAbsolute(absoluteDate float64) HinduLunarDate {
+	critical := HinduSunrise(absoluteDate)
+	day := hinduLunarDayFromMoment(critical)
+	leapDay := day == hinduLunarDayFromMoment(HinduSunrise(absoluteDate-1))
+	lastNewMoon := HinduNewMoonBefore(critical)
+	nextNewMoon := HinduNewMoonBefore(Moment(math.Floor(float64(lastNewMoon)) + 35))
+	solarMonth := HinduZodiac(lastNewMoon)
+	leapMonth := solarMonth == HinduZodiac(nextNewMoon)
+	month := amod(solarMonth+1, 12)
+	t := absoluteDate
+	if month <= 2 {
+		t += 180
+	}
+	year := hinduCalendarYear(Moment(t)) - hinduLunarEra
+	return HinduLunarDate{year, month, leapMonth, day, leapDay}
+}
+
+// AbsoluteFromHinduLunar returns the absolute (fixed) date corresponding to a
+// given modern Hindu lunar date. For dates that do not exist, e.g. an
+// expunged lunar day, the result is the date of the following lunar day.
+func AbsoluteFromHinduLunar(d HinduLunarDate) (absoluteDate float64) {
+	approx := oldHinduEpoch + hinduSiderealYear*(d.Year+hinduLunarEra+d.Month/12)
+	s := math.Floor(approx - hinduSiderealYear/360*
+		(mod(HinduSolarLongitude(Moment(approx))-(d.Month-1)*30+180, 360)-180))
+	k := hinduLunarDayFromMoment(Moment(s + 0.25))
+	// the lunar day at s, counted from the beginning of the month sought
+	elapsed := k
+	if k <= 3 || k >= 27 {
+		if mid := HinduLunarFromAbsolute(s - 15); mid.Month != d.Month || (mid.LeapMonth && !d.LeapMonth) {
+			elapsed = mod3(k, -15, 15)
+		} else {
+			elapsed = mod3(k, 15, 45)
+		}
+	}
+	// find returns the first date from an estimate on which the lunar day
+	// (or, if it was expunged, the following one) is current at sunrise.
+	find := func(est float64) float64 {
+		tau := est - mod3(hinduLunarDayFromMoment(Moment(est+0.25))-d.Day, -15, 15)
+		date := tau - 1
+		for {
+			day := hinduLunarDayFromMoment(HinduSunrise(date))
+			if day == d.Day || day == amod(d.Day+1, 30) {
+				return date
+			}
+			date++
+		}
+	}
+	date := find(s + d.Day - elapsed)
+	// a leap month precedes the ordinary month of the same name
+	if d.LeapMonth && !HinduLunarFromAbsolute(date).LeapMonth {
+		date = find(date - 30)
+	}
+	if d.LeapDay {
+		return date + 1
+	}
+	return date
+}
+
+// Panchang holds the elements of the Hindu almanac (panchanga) of a day, as
+// current at sunrise in Ujjain.
+type Panchang struct {
+	Tithi     float64 // lunar day, 1 to 30
+	Vara      Weekday // day of the week
+	Nakshatra float64 // lunar station of the moon, 1 to 27
+	Yoga      float64 // sum of the longitudes of sun and moon, 1 to 27
+	Karana    float64 // half lunar day, 0 (Kimstughna) to 10 (Naga)
+}
+
+// karana returns the karana of the nth half lunar day (1 to 60) of a month:
+// four fixed karanas at the new moon, and seven repeating ones in between.
+func karana(n float64) float64 {
+	switch {
+	case n == 1:
+		return 0
+	case n > 57:
+		return n - 50
+	default:
+		return amod(n-1, 7)
+	}
+}
+
+// PanchangFromAbsolute returns the panchang of a given absolute date.
+func PanchangFromAbsolute(absoluteDate float64) Panchang {
+	sunrise := HinduSunrise(absoluteDate)
+	solar, lunar := HinduSolarLongitude(sunrise), HinduLunarLongitude(sunrise)
+	const station = 40.0 / 3 // 800 arc minutes
+	return Panchang{
+		Tithi:     hinduLunarDayFromMoment(sunrise),
+		Vara:      DayOfWeek(absoluteDate),
+		Nakshatra: math.Floor(lunar/station) + 1,
+		Yoga:      math.Floor(mod(solar+lunar, 360)/station) + 1,
+		Karana:    karana(math.Floor(hinduLunarPhase(sunrise)/6) + 1),
+	}
+}
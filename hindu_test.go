@@ -0,0 +1,145 @@
+// Copyright (C) 2022  Alexander Staudt
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package libcalendar
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestHinduSolar(t *testing.T) {
+	var tests = []struct {
+		absoluteDate float64
+		want         HinduSolarDate
+	}{
+		{-214193, HinduSolarDate{-664, 5, 19}},
+		{738259, HinduSolarDate{1944, 1, 1}}, // Mesha Sankranti, 14 April 2022
+		{738452, HinduSolarDate{1944, 7, 7}},
+	}
+	for _, tt := range tests {
+		testname := fmt.Sprint(tt.absoluteDate)
+		t.Run(testname, func(t *testing.T) {
+			ans := HinduSolarFromAbsolute(tt.absoluteDate)
+			t.Logf("got %v, want %v", ans, tt.want)
+			if ans != tt.want || AbsoluteFromHinduSolar(ans) != tt.absoluteDate {
+				t.Errorf("got %v, want %v", ans, tt.want)
+			}
+		})
+	}
+}
+
+func TestHinduLunar(t *testing.T) {
+	var tests = []struct {
+		absoluteDate float64
+		want         HinduLunarDate
+	}{
+		{-214193, HinduLunarDate{-529, 6, false, 11, false}},
+		{738247, HinduLunarDate{2079, 1, false, 1, false}}, // Ugadi, 2 April 2022
+		{738452, HinduLunarDate{2079, 7, false, 29, false}},
+		{738453, HinduLunarDate{2079, 7, false, 30, false}},
+		{738721, HinduLunarDate{2080, 5, true, 3, false}},
+		// sample data of Calendrical Calculations
+		{-61387, HinduLunarDate{-111, 9, false, 27, false}},
+		{25469, HinduLunarDate{127, 8, false, 3, false}},
+		{544676, HinduLunarDate{1549, 2, true, 3, false}},
+		{709409, HinduLunarDate{2000, 1, false, 14, false}},
+		{744313, HinduLunarDate{2095, 8, false, 14, false}},
+	}
+	for _, tt := range tests {
+		testname := fmt.Sprint(tt.absoluteDate)
+		t.Run(testname, func(t *testing.T) {
+			ans := HinduLunarFromAbsolute(tt.absoluteDate)
+			t.Logf("got %v, want %v", ans, tt.want)
+			if ans != tt.want || AbsoluteFromHinduLunar(ans) != tt.absoluteDate {
+				t.Errorf("got %v, want %v", ans, tt.want)
+			}
+		})
+	}
+
+	t.Run("round trip", func(t *testing.T) {
+		for d := 737000.0; d < 740000; d += 7 {
+			if ans := AbsoluteFromHinduLunar(HinduLunarFromAbsolute(d)); ans != d {
+				t.Errorf("got %v, want %v", ans, d)
+			}
+			if ans := AbsoluteFromHinduSolar(HinduSolarFromAbsolute(d)); ans != d {
+				t.Errorf("got %v, want %v", ans, d)
+			}
+		}
+	})
+}
+
+func TestHinduSunrise(t *testing.T) {
+	// The Surya Siddhanta sunrise follows the astronomical sunrise at Ujjain
+	// (see TestSunriseSunset) to within a quarter of an hour; with the
+	// equation of time reversed, it is off by up to half an hour.
+	for d := AbsoluteFromGregorian(GregorianDate{2022, 1, 1}); d <= AbsoluteFromGregorian(GregorianDate{2022, 12, 31}); d++ {
+		sunrise, _ := Sunrise(d, ujjain)
+		want := LocalFromStandard(sunrise, ujjain)
+		ans := HinduSunrise(d)
+		if math.Abs(float64(ans-want))*24*60 > 16 {
+			t.Errorf("%v: got %v, want %v", GregorianFromAbsolute(d), ans, want)
+		}
+	}
+}
+
+func TestHinduDates(t *testing.T) {
+	var tests = []struct {
+		calendar   string
+		components []float64
+		want       string
+		err        error
+	}{
+		{"hinduSolar", []float64{1944, 1, 1}, "1 Mesha 1944", nil},
+		{"hinduLunar", []float64{2079, 7, 30}, "30 Asvina 2079", nil},
+		{"hinduLunar", []float64{2080, 5, 3, 1, 0}, "3 Adhika Sravana 2080", nil},
+		{"hinduLunar", []float64{2078, 5, 27, 0, 1}, "27 (adhika) Sravana 2078", nil},
+		{"hinduLunar", []float64{2078, 5, 28, 0, 1}, "", ErrInvalidDate},
+		{"hinduLunar", []float64{2079, 5, 3, 1, 0}, "", ErrInvalidDate},
+	}
+	for _, tt := range tests {
+		testname := fmt.Sprint(tt.calendar, tt.components)
+		t.Run(testname, func(t *testing.T) {
+			d := DateFromComponents(tt.calendar, tt.components)
+			err := ValidateDate(d)
+			t.Logf("got %v (%v), want %v (%v)", d, err, tt.want, tt.err)
+			if err != tt.err || (err == nil && d.String() != tt.want) {
+				t.Errorf("got %v (%v), want %v (%v)", d, err, tt.want, tt.err)
+			}
+		})
+	}
+}
+
+func TestPanchang(t *testing.T) {
+	var tests = []struct {
+		absoluteDate float64
+		want         string
+	}{
+		{738247, "Shukla Pratipada, Shanivara, Revati, Indra, Bava"},
+		{738452, "Krishna Chaturdashi, Somavara, Hasta, Vaidhriti, Shakuni"},
+		{738453, "Amavasya, Mangalavara, Chitra, Vishkambha, Naga"},
+	}
+	for _, tt := range tests {
+		testname := fmt.Sprint(tt.absoluteDate)
+		t.Run(testname, func(t *testing.T) {
+			ans := PanchangFromAbsolute(tt.absoluteDate).String()
+			t.Logf("got %v, want %v", ans, tt.want)
+			if ans != tt.want {
+				t.Errorf("got %v, want %v", ans, tt.want)
+			}
+		})
+	}
+}
@@ -24,7 +24,10 @@
 
 package libcalendar
 
-import "math"
+import (
+	"math"
+	"sort"
+)
 
 // Holidays
 
@@ -177,6 +180,197 @@ func MuladAlNabi(year float64) (absoluteDates []float64) {
 	return IslamicDatesInGregorianYear(rabi_i, 12, year)
 }
 
+// Hindu holidays
+
+// hinduLunarOnOrBefore reports whether Hindu lunar date d falls on or before
+// Hindu lunar date other. Leap months precede the ordinary months of the same
+// name, leap days follow the ordinary days.
+func hinduLunarOnOrBefore(d, other HinduLunarDate) bool {
+	switch {
+	case d.Year != other.Year:
+		return d.Year < other.Year
+	case d.Month != other.Month:
+		return d.Month < other.Month
+	case d.LeapMonth != other.LeapMonth:
+		return d.LeapMonth
+	case d.Day != other.Day:
+		return d.Day < other.Day
+	default:
+		return !d.LeapDay || other.LeapDay
+	}
+}
+
+// hinduDateOccur returns the absolute date on which a given day of an
+// ordinary month of a Hindu lunar year is observed, by the lunar day current
+// at sunrise. If the lunar day is
+// expunged, it is observed on the preceding day; if the month is expunged, on
+// the last day before the following month.
+func hinduDateOccur(year, month, day float64) (absoluteDate float64) {
+	try := AbsoluteFromHinduLunar(HinduLunarDate{year, month, false, day, false})
+	mid := HinduLunarFromAbsolute(try)
+	if day > 15 {
+		mid = HinduLunarFromAbsolute(try - 5)
+	}
+	if mid.Month != month {
+		target := HinduLunarDate{mid.Year, mid.Month, mid.LeapMonth, day, false}
+		for absoluteDate = try; hinduLunarOnOrBefore(HinduLunarFromAbsolute(absoluteDate), target); absoluteDate++ {
+		}
+		return absoluteDate - 1
+	}
+	if HinduLunarFromAbsolute(try).Day != day {
+		return try - 1
+	}
+	return try
+}
+
+// HinduLunarDatesInGregorianYear returns a slice of absolute dates on which
+// a given Hindu lunar date (month, day) is observed in a given Gregorian year.
+func HinduLunarDatesInGregorianYear(month float64, day float64, year float64) (absoluteDates []float64) {
+	jan_1 := AbsoluteFromGregorian(GregorianDate{year, january, 1})
+	dec_31 := AbsoluteFromGregorian(GregorianDate{year, december, 31})
+	y := HinduLunarFromAbsolute(jan_1).Year
+	absoluteDates = make([]float64, 0, 2)
+	for _, date := range []float64{hinduDateOccur(y, month, day), hinduDateOccur(y+1, month, day)} {
+		if jan_1 <= date && date <= dec_31 {
+			absoluteDates = append(absoluteDates, date)
+		}
+	}
+	return absoluteDates
+}
+
+// MakarSankranti returns the absolute (fixed) date of Makar Sankranti, the
+// day on which the sun enters the sign of Capricorn (Makara), in a given
+// Gregorian year.
+func MakarSankranti(year float64) (absoluteDate float64) {
+	jan_1 := AbsoluteFromGregorian(GregorianDate{year, january, 1})
+	return AbsoluteFromHinduSolar(HinduSolarDate{HinduSolarFromAbsolute(jan_1).Year, makara, 1})
+}
+
+// Pongal returns the absolute (fixed) date of Thai Pongal, the first day of
+// the Tamil month Thai, in a given Gregorian year. Thai begins with the
+// entry of the sun into Capricorn, so Pongal falls on Makar Sankranti.
+func Pongal(year float64) (absoluteDate float64) {
+	return MakarSankranti(year)
+}
+
+// MahaShivaratri returns a slice of absolute (fixed) dates of Maha
+// Shivaratri, on the 14th day of the waning moon of Magha, that occur in a
+// given Gregorian year. It is observed on the day at whose midnight that
+// lunar day is current.
+func MahaShivaratri(year float64) (absoluteDates []float64) {
+	return hinduObservedAt(HinduLunarDatesInGregorianYear(magha, 29, year), 29, hinduMidnight)
+}
+
+// Holi returns a slice of absolute (fixed) dates of Holi, on the full moon of
+// Phalguna, that occur in a given Gregorian year.
+func Holi(year float64) (absoluteDates []float64) {
+	return HinduLunarDatesInGregorianYear(phalguna, 15, year)
+}
+
+// RamaNavami returns a slice of absolute (fixed) dates of Rama Navami, on the
+// 9th day of the waxing moon of Chaitra, that occur in a given Gregorian
+// year.
+func RamaNavami(year float64) (absoluteDates []float64) {
+	return HinduLunarDatesInGregorianYear(chaitra, 9, year)
+}
+
+// Janmashtami returns a slice of absolute (fixed) dates of Krishna
+// Janmashtami, on the 8th day of the waning moon of Sravana, that occur in a
+// given Gregorian year. It is observed on the day at whose midnight that
+// lunar day is current.
+func Janmashtami(year float64) (absoluteDates []float64) {
+	return hinduObservedAt(HinduLunarDatesInGregorianYear(sravana, 23, year), 23, hinduMidnight)
+}
+
+// GaneshChaturthi returns a slice of absolute (fixed) dates of Ganesh
+// Chaturthi, on the 4th day of the waxing moon of Bhadrapada, that occur in
+// a given Gregorian year.
+func GaneshChaturthi(year float64) (absoluteDates []float64) {
+	return HinduLunarDatesInGregorianYear(bhadrapada, 4, year)
+}
+
+// Navaratri returns a slice of absolute (fixed) dates of the first of the
+// nine nights of (Sharad) Navaratri, on the 1st day of the waxing moon of
+// Asvina, that occur in a given Gregorian year.
+func Navaratri(year float64) (absoluteDates []float64) {
+	return HinduLunarDatesInGregorianYear(asvina, 1, year)
+}
+
+// Dussehra returns a slice of absolute (fixed) dates of Dussehra (Vijayadashami),
+// on the 10th day of the waxing moon of Asvina, that occur in a given
+// Gregorian year.
+func Dussehra(year float64) (absoluteDates []float64) {
+	return HinduLunarDatesInGregorianYear(asvina, 10, year)
+}
+
+// hinduObservedAt returns the dates of a Hindu lunar holiday observed when a
+// given lunar day is current at a time of day (e.g. at midnight) rather than
+// at sunrise: each date is moved to the preceding day if the lunar day is
+// already current at the time returned by at for that day.
+func hinduObservedAt(absoluteDates []float64, day float64, at func(float64) (Moment, bool)) []float64 {
+	for i, date := range absoluteDates {
+		if t, ok := at(date - 1); ok && hinduLunarDayFromMoment(t) == day {
+			absoluteDates[i] = date - 1
+		}
+	}
+	return absoluteDates
+}
+
+// hinduMidnight returns the midnight (in Ujjain) following a given absolute
+// date.
+func hinduMidnight(absoluteDate float64) (Moment, bool) {
+	return Moment(absoluteDate + 1), true
+}
+
+// hinduSunset returns the sunset in Ujjain on a given absolute date.
+func hinduSunset(absoluteDate float64) (Moment, bool) {
+	return Sunset(absoluteDate, ujjain)
+}
+
+// Diwali returns a slice of absolute (fixed) dates of Diwali (Lakshmi Puja),
+// on the new moon of Asvina, that occur in a given Gregorian year. Diwali is
+// observed on the day at whose sunset the new moon day is current.
+func Diwali(year float64) (absoluteDates []float64) {
+	return hinduObservedAt(HinduLunarDatesInGregorianYear(asvina, 30, year), 30, hinduSunset)
+}
+
+// Bahá'í holidays
+
+// NawRuz returns the absolute (fixed) date of Naw-Rúz, the Bahá'í New Year,
+// in a given Gregorian year.
+func NawRuz(year float64) (absoluteDate float64) {
+	return bahaiNewYear(year - 1843)
+}
+
+// BirthOfTheBab returns the absolute (fixed) date of the Birth of the Báb in
+// a given Gregorian year. Until 2014 it was observed on 20 October; since
+// 2015 it falls on the day after the eighth new moon after Naw-Rúz, with days
+// beginning at sunset in Tehran.
+func BirthOfTheBab(year float64) (absoluteDate float64) {
+	if year-1843 < bahaiAstronomicalYear {
+		return AbsoluteFromGregorian(GregorianDate{year, 10, 20})
+	}
+	nawRuz := NawRuz(year)
+	newMoon := UniversalFromStandard(DayStart("bahai", nawRuz, tehran), tehran)
+	for i := 0; i < 8; i++ {
+		newMoon = NewMoonAtOrAfter(newMoon)
+		if i < 7 {
+			newMoon++
+		}
+	}
+	return AbsoluteFromMoment(newMoon, "bahai", tehran) + 1
+}
+
+// BirthOfBahaullah returns the absolute (fixed) date of the Birth of
+// Bahá'u'lláh in a given Gregorian year: until 2014 on 12 November, since
+// 2015 on the day after the Birth of the Báb.
+func BirthOfBahaullah(year float64) (absoluteDate float64) {
+	if year-1843 < bahaiAstronomicalYear {
+		return AbsoluteFromGregorian(GregorianDate{year, 11, 12})
+	}
+	return BirthOfTheBab(year) + 1
+}
+
 // Jewish holidays
 
 // YomKippur returns the absolute (fixed) date of Yom Kippur in a given
@@ -218,6 +412,62 @@ func TishaBAv(year float64) (absoluteDate float64) {
 	}
 }
 
+// yomTovDays lists the festival days on which work is forbidden (yamim
+// tovim) by Hebrew month and day. Days marked diaspora are only observed
+// outside Israel.
+var yomTovDays = []struct {
+	name     string
+	month    float64
+	day      float64
+	diaspora bool
+}{
+	{"Passover", nisan, 15, false},
+	{"Passover", nisan, 16, true},
+	{"Passover", nisan, 21, false},
+	{"Passover", nisan, 22, true},
+	{"Shavuot", sivan, 6, false},
+	{"Shavuot", sivan, 7, true},
+	{"Rosh Hashanah", tishri, 1, false},
+	{"Rosh Hashanah", tishri, 2, false},
+	{"Yom Kippur", tishri, 10, false},
+	{"Sukkot", tishri, 15, false},
+	{"Sukkot", tishri, 16, true},
+	{"Shemini Atzeret", tishri, 22, false},
+	{"Simchat Torah", tishri, 23, true},
+}
+
+// YomTovName returns the name of the festival falling on a given absolute
+// date if work is forbidden on that date, observing the second festival days
+// of the diaspora if diaspora is true.
+func YomTovName(absoluteDate float64, diaspora bool) (name string, ok bool) {
+	d := HebrewFromAbsolute(absoluteDate)
+	for _, y := range yomTovDays {
+		if y.month == d.Month && y.day == d.Day && (diaspora || !y.diaspora) {
+			return y.name, true
+		}
+	}
+	return "", false
+}
+
+// YomTov returns the festival days on which work is forbidden in a given
+// Gregorian year, including the second days observed outside Israel if
+// diaspora is true.
+func YomTov(year float64, diaspora bool) []Holiday {
+	holidays := []Holiday{}
+	for _, y := range yomTovDays {
+		if y.diaspora && !diaspora {
+			continue
+		}
+		hebrewYear := year + 3760
+		if y.month >= tishri {
+			hebrewYear++
+		}
+		holidays = append(holidays, Holiday{y.name, AbsoluteFromHebrew(HebrewDate{hebrewYear, y.month, y.day})})
+	}
+	sortHolidays(holidays)
+	return holidays
+}
+
 // HebrewBirthday determines the absolute (fixed) date of the anniversary of a
 // given Hebrew birth date in a given Hebrew year.
 func HebrewBirthday(birthdate HebrewDate, year float64) (absoluteDate float64) {
@@ -250,3 +500,130 @@ func Yahrzeit(deathDate HebrewDate, year float64) (absoluteDate float64) {
 		return AbsoluteFromHebrew(HebrewDate{year, deathMonth, deathDay})
 	}
 }
+
+// Lists of holidays
+
+// Holiday represents an occurrence of a named holiday.
+type Holiday struct {
+	Name string  // e.g. "Passover"
+	Date float64 // absolute (fixed) date
+}
+
+// HolidaysInGregorianYear returns the occurrences of the holidays defined in
+// this file in a given Gregorian year, sorted by date.
+func HolidaysInGregorianYear(year float64) []Holiday {
+	holidays := []Holiday{
+		{"Independence Day", IndependenceDay(year)},
+		{"Labor Day", LaborDay(year)},
+		{"Memorial Day", MemorialDay(year)},
+		{"Daylight Saving Time Begins", DaylightSavingsStart(year)},
+		{"Daylight Saving Time Ends", DaylightSavingsEnd(year)},
+		{"Christmas", Christmas(year)},
+		{"Advent", Advent(year)},
+		{"Epiphany", Epiphany(year - 1)},
+		{"Eastern Orthodox Easter", NicaeanRuleEaster(year)},
+		{"Easter", Easter(year)},
+		{"Pentecost", Pentecost(year)},
+		{"Yom Kippur", YomKippur(year)},
+		{"Passover", Passover(year)},
+		{"Purim", Purim(year)},
+		{"Ta'anit Esther", TaAnitEsther(year)},
+		{"Tisha B'Av", TishaBAv(year)},
+	}
+	for _, date := range EasternOrthodoxChristmas(year) {
+		holidays = append(holidays, Holiday{"Eastern Orthodox Christmas", date})
+	}
+	for _, date := range MuladAlNabi(year) {
+		holidays = append(holidays, Holiday{"Mulad al-Nabi", date})
+	}
+	holidays = append(holidays,
+		Holiday{"Makar Sankranti", MakarSankranti(year)},
+		Holiday{"Pongal", Pongal(year)},
+		Holiday{"Naw-Rúz", NawRuz(year)},
+		Holiday{"Birth of the Báb", BirthOfTheBab(year)},
+		Holiday{"Birth of Bahá'u'lláh", BirthOfBahaullah(year)},
+	)
+	for _, h := range []struct {
+		name  string
+		dates func(float64) []float64
+	}{
+		{"Maha Shivaratri", MahaShivaratri},
+		{"Holi", Holi},
+		{"Rama Navami", RamaNavami},
+		{"Janmashtami", Janmashtami},
+		{"Ganesh Chaturthi", GaneshChaturthi},
+		{"Navaratri", Navaratri},
+		{"Dussehra", Dussehra},
+		{"Diwali", Diwali},
+	} {
+		for _, date := range h.dates(year) {
+			holidays = append(holidays, Holiday{h.name, date})
+		}
+	}
+	sortHolidays(holidays)
+	return holidays
+}
+
+// sortHolidays sorts holidays by date, and holidays on the same date by name.
+func sortHolidays(holidays []Holiday) {
+	sort.SliceStable(holidays, func(i, j int) bool {
+		if holidays[i].Date != holidays[j].Date {
+			return holidays[i].Date < holidays[j].Date
+		}
+		return holidays[i].Name < holidays[j].Name
+	})
+}
+
+// HolidaysInRange returns the occurrences of the holidays listed by
+// HolidaysInGregorianYear from absolute date `from` through absolute date
+// `to`, sorted by date.
+func HolidaysInRange(from, to float64) []Holiday {
+	holidays := []Holiday{}
+	first := GregorianFromAbsolute(from).Year
+	last := GregorianFromAbsolute(to).Year
+	for year := first; year <= last; year++ {
+		for _, h := range HolidaysInGregorianYear(year) {
+			if from <= h.Date && h.Date <= to {
+				holidays = append(holidays, h)
+			}
+		}
+	}
+	return holidays
+}
+
+// Anniversary is a recurring anniversary of a Hebrew date, observed on the
+// dates given by HebrewBirthday or, for a yahrzeit, by Yahrzeit.
+type Anniversary struct {
+	Name     string     // e.g. "Yahrzeit of Moshe"
+	Date     HebrewDate // birth or death date
+	Yahrzeit bool       // true for a yahrzeit, false for a birthday
+}
+
+// Occurrences returns the anniversaries of its receiver from absolute date
+// `from` through absolute date `to`, sorted by date.
+func (a Anniversary) Occurrences(from, to float64) []Holiday {
+	holidays := []Holiday{}
+	first := math.Max(HebrewFromAbsolute(from).Year, a.Date.Year+1)
+	last := HebrewFromAbsolute(to).Year
+	for year := first; year <= last; year++ {
+		date := HebrewBirthday(a.Date, year)
+		if a.Yahrzeit {
+			date = Yahrzeit(a.Date, year)
+		}
+		if from <= date && date <= to {
+			holidays = append(holidays, Holiday{a.Name, date})
+		}
+	}
+	return holidays
+}
+
+// AnniversariesInRange returns the occurrences of several anniversaries from
+// absolute date `from` through absolute date `to`, sorted by date.
+func AnniversariesInRange(anniversaries []Anniversary, from, to float64) []Holiday {
+	holidays := []Holiday{}
+	for _, a := range anniversaries {
+		holidays = append(holidays, a.Occurrences(from, to)...)
+	}
+	sortHolidays(holidays)
+	return holidays
+}
@@ -422,3 +422,98 @@ func TestTishaBAv(t *testing.T) {
 
 // hebrew birthday
 // hebrew yahrzeit
+
+func TestAnniversaryOccurrences(t *testing.T) {
+	var tests = []struct {
+		anniversary Anniversary
+		from, to    float64
+		want        []float64
+	}{
+		// birthday on 30 Adar I of a leap year is observed on the last day of Adar
+		{Anniversary{"Birthday", HebrewDate{5782, 12, 30}, false}, 738000, 738900,
+			[]float64{AbsoluteFromHebrew(HebrewDate{5783, 12, 30})}},
+		// no anniversary before or in the year of the date itself
+		{Anniversary{"Birthday", HebrewDate{5782, 3, 16}, false}, 738000, 738400, []float64{}},
+		{Anniversary{"Yahrzeit", HebrewDate{5750, 8, 30}, true}, 738000, 738500,
+			[]float64{AbsoluteFromHebrew(HebrewDate{5782, 8, 30}), AbsoluteFromHebrew(HebrewDate{5783, 9, 1})}},
+	}
+	for _, tt := range tests {
+		testname := fmt.Sprintf("%v", tt.anniversary)
+		t.Run(testname, func(t *testing.T) {
+			ans := []float64{}
+			for _, h := range tt.anniversary.Occurrences(tt.from, tt.to) {
+				ans = append(ans, h.Date)
+			}
+			t.Logf("got %v, want %v", ans, tt.want)
+			if fmt.Sprint(ans) != fmt.Sprint(tt.want) {
+				t.Errorf("got %v, want %v", ans, tt.want)
+			}
+		})
+	}
+}
+
+func TestYomTov(t *testing.T) {
+	var tests = []struct {
+		year     float64
+		diaspora bool
+		want     []Holiday
+	}{
+		{2022, false, []Holiday{{"Passover", 738261}, {"Passover", 738267}, {"Shavuot", 738311},
+			{"Rosh Hashanah", 738424}, {"Rosh Hashanah", 738425}, {"Yom Kippur", 738433},
+			{"Sukkot", 738438}, {"Shemini Atzeret", 738445}}},
+		{2022, true, []Holiday{{"Passover", 738261}, {"Passover", 738262}, {"Passover", 738267}, {"Passover", 738268},
+			{"Shavuot", 738311}, {"Shavuot", 738312}, {"Rosh Hashanah", 738424}, {"Rosh Hashanah", 738425},
+			{"Yom Kippur", 738433}, {"Sukkot", 738438}, {"Sukkot", 738439}, {"Shemini Atzeret", 738445},
+			{"Simchat Torah", 738446}}},
+	}
+	for _, tt := range tests {
+		testname := fmt.Sprintf("%v %v", tt.year, tt.diaspora)
+		t.Run(testname, func(t *testing.T) {
+			ans := YomTov(tt.year, tt.diaspora)
+			t.Logf("got %v, want %v", ans, tt.want)
+			if fmt.Sprint(ans) != fmt.Sprint(tt.want) {
+				t.Errorf("got %v, want %v", ans, tt.want)
+			}
+			for _, h := range ans {
+				if name, ok := YomTovName(h.Date, tt.diaspora); !ok || name != h.Name {
+					t.Errorf("got %v, want %v", name, h.Name)
+				}
+			}
+		})
+	}
+}
+
+func TestHinduHolidays(t *testing.T) {
+	var tests = []struct {
+		name  string
+		dates func(float64) []float64
+		year  float64
+		want  []float64
+	}{
+		{"Maha Shivaratri", MahaShivaratri, 2022, []float64{AbsoluteFromGregorian(GregorianDate{2022, 3, 1})}},
+		{"Maha Shivaratri", MahaShivaratri, 2024, []float64{AbsoluteFromGregorian(GregorianDate{2024, 3, 8})}},
+		{"Holi", Holi, 2022, []float64{AbsoluteFromGregorian(GregorianDate{2022, 3, 18})}},
+		{"Rama Navami", RamaNavami, 2023, []float64{AbsoluteFromGregorian(GregorianDate{2023, 3, 30})}},
+		{"Janmashtami", Janmashtami, 2022, []float64{AbsoluteFromGregorian(GregorianDate{2022, 8, 18})}},
+		{"Janmashtami", Janmashtami, 2024, []float64{AbsoluteFromGregorian(GregorianDate{2024, 8, 26})}},
+		{"Ganesh Chaturthi", GaneshChaturthi, 2023, []float64{AbsoluteFromGregorian(GregorianDate{2023, 9, 19})}},
+		{"Navaratri", Navaratri, 2022, []float64{AbsoluteFromGregorian(GregorianDate{2022, 9, 26})}},
+		{"Dussehra", Dussehra, 2023, []float64{AbsoluteFromGregorian(GregorianDate{2023, 10, 24})}},
+		{"Diwali", Diwali, 2022, []float64{AbsoluteFromGregorian(GregorianDate{2022, 10, 24})}},
+		{"Diwali", Diwali, 2023, []float64{AbsoluteFromGregorian(GregorianDate{2023, 11, 12})}},
+		{"Makar Sankranti", func(year float64) []float64 { return []float64{MakarSankranti(year)} }, 2022,
+			[]float64{AbsoluteFromGregorian(GregorianDate{2022, 1, 14})}},
+		{"Pongal", func(year float64) []float64 { return []float64{Pongal(year)} }, 2024,
+			[]float64{AbsoluteFromGregorian(GregorianDate{2024, 1, 15})}},
+	}
+	for _, tt := range tests {
+		testname := fmt.Sprintf("%v %.0f", tt.name, tt.year)
+		t.Run(testname, func(t *testing.T) {
+			got := tt.dates(tt.year)
+			t.Logf("got %v, want %v", got, tt.want)
+			if !equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
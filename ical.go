@@ -0,0 +1,210 @@
+// Copyright (C) 2022  Alexander Staudt
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// This file implements the export of holidays as iCalendar (RFC 5545) streams
+// of all-day events, and the import of such streams.
+
+package libcalendar
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ErrInvalidICalendar is returned by ParseICalendar for malformed streams.
+var ErrInvalidICalendar = errors.New("libcalendar: invalid iCalendar stream")
+
+// ICalendarOptions control how WriteICalendar writes events.
+type ICalendarOptions struct {
+	Name      string    // calendar name (X-WR-CALNAME), if not empty
+	Domain    string    // right-hand side of the UIDs, defaults to "libcalendar"
+	Calendars []string  // calendars noted in the DESCRIPTION, defaults to hebrew and islamic
+	Stamp     time.Time // DTSTAMP of the events, defaults to the current time
+}
+
+// icalDate returns the iCalendar DATE value of an absolute date.
+func icalDate(absoluteDate float64) string {
+	d := GregorianFromAbsolute(absoluteDate)
+	return fmt.Sprintf("%04.0f%02.0f%02.0f", d.Year, d.Month, d.Day)
+}
+
+// icalUID returns a UID derived from the name and date of a holiday, so that
+// repeated exports of the same occurrence yield the same UID.
+func icalUID(h Holiday, domain string) string {
+	sum := sha1.Sum([]byte(h.Name + "\x00" + icalDate(h.Date)))
+	return hex.EncodeToString(sum[:10]) + "@" + domain
+}
+
+// icalEscape escapes a TEXT value.
+var icalEscape = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`)
+
+// icalUnescape reverses icalEscape.
+func icalUnescape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			i++
+			if s[i] == 'n' || s[i] == 'N' {
+				b.WriteByte('\n')
+				continue
+			}
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// icalFold folds a content line into lines of at most 75 octets, without
+// splitting UTF-8 sequences, and terminates it with CRLF.
+func icalFold(line string) string {
+	var b strings.Builder
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut] + "\r\n ")
+		line = line[cut:]
+		limit = 74 // the leading blank counts
+	}
+	b.WriteString(line + "\r\n")
+	return b.String()
+}
+
+// WriteICalendar writes holidays as a VCALENDAR stream of all-day VEVENTs.
+// The DESCRIPTION of each event notes its date in the calendars given by the
+// options. Anniversaries are written by passing their Occurrences.
+func WriteICalendar(w io.Writer, holidays []Holiday, opts ICalendarOptions) error {
+	if opts.Domain == "" {
+		opts.Domain = "libcalendar"
+	}
+	if opts.Calendars == nil {
+		opts.Calendars = []string{"hebrew", "islamic"}
+	}
+	if opts.Stamp.IsZero() {
+		opts.Stamp = time.Now()
+	}
+	stamp := opts.Stamp.UTC().Format("20060102T150405Z")
+
+	var b strings.Builder
+	line := func(s string) { b.WriteString(icalFold(s)) }
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//staudtlex.de//libcalendar//EN")
+	line("CALSCALE:GREGORIAN")
+	if opts.Name != "" {
+		line("X-WR-CALNAME:" + icalEscape.Replace(opts.Name))
+	}
+	for _, h := range holidays {
+		var notes []string
+		for _, c := range opts.Calendars {
+			notes = append(notes, c+": "+FromAbsolute(h.Date, c))
+		}
+		line("BEGIN:VEVENT")
+		line("UID:" + icalUID(h, opts.Domain))
+		line("DTSTAMP:" + stamp)
+		line("DTSTART;VALUE=DATE:" + icalDate(h.Date))
+		line("DTEND;VALUE=DATE:" + icalDate(h.Date+1))
+		line("SUMMARY:" + icalEscape.Replace(h.Name))
+		if len(notes) > 0 {
+			line("DESCRIPTION:" + icalEscape.Replace(strings.Join(notes, "\n")))
+		}
+		line("TRANSP:TRANSPARENT")
+		line("END:VEVENT")
+	}
+	line("END:VCALENDAR")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// icalLines returns the unfolded content lines of an iCalendar stream.
+func icalLines(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		l := strings.TrimRight(scanner.Text(), "\r")
+		switch {
+		case l == "":
+		case (l[0] == ' ' || l[0] == '\t') && len(lines) > 0:
+			lines[len(lines)-1] += l[1:]
+		default:
+			lines = append(lines, l)
+		}
+	}
+	return lines, scanner.Err()
+}
+
+// ParseICalendar reads the VEVENTs of an iCalendar stream as holidays named
+// by their SUMMARY and dated by their DTSTART (the date part of DATE-TIME
+// values). Recurrence rules are not expanded.
+func ParseICalendar(r io.Reader) ([]Holiday, error) {
+	lines, err := icalLines(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(lines) == 0 || !strings.EqualFold(lines[0], "BEGIN:VCALENDAR") {
+		return nil, ErrInvalidICalendar
+	}
+	holidays := []Holiday{}
+	var event *Holiday
+	dated, ended := false, false
+	for i, l := range lines[1:] {
+		colon := strings.IndexByte(l, ':')
+		if colon < 0 {
+			return nil, fmt.Errorf("%w: line %d", ErrInvalidICalendar, i+2)
+		}
+		name, value := l[:colon], l[colon+1:]
+		if semicolon := strings.IndexByte(name, ';'); semicolon >= 0 {
+			name = name[:semicolon] // parameters, e.g. VALUE=DATE
+		}
+		switch name = strings.ToUpper(name); {
+		case name == "BEGIN" && strings.EqualFold(value, "VEVENT"):
+			event, dated = &Holiday{}, false
+		case name == "END" && strings.EqualFold(value, "VEVENT") && event != nil:
+			if !dated {
+				return nil, fmt.Errorf("%w: event without DTSTART", ErrInvalidICalendar)
+			}
+			holidays = append(holidays, *event)
+			event = nil
+		case name == "END" && strings.EqualFold(value, "VCALENDAR"):
+			ended = true
+		case event == nil:
+		case name == "SUMMARY":
+			event.Name = icalUnescape(value)
+		case name == "DTSTART":
+			var year, month, day int
+			if len(value) < 8 {
+				return nil, fmt.Errorf("%w: DTSTART %q", ErrInvalidICalendar, value)
+			}
+			if _, err := fmt.Sscanf(value[:8], "%4d%2d%2d", &year, &month, &day); err != nil {
+				return nil, fmt.Errorf("%w: DTSTART %q", ErrInvalidICalendar, value)
+			}
+			event.Date = AbsoluteFromGregorian(GregorianDate{float64(year), float64(month), float64(day)})
+			dated = true
+		}
+	}
+	if event != nil || !ended {
+		return nil, fmt.Errorf("%w: unterminated component", ErrInvalidICalendar)
+	}
+	return holidays, nil
+}
@@ -0,0 +1,120 @@
+// Copyright (C) 2022  Alexander Staudt
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package libcalendar
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteICalendar(t *testing.T) {
+	holidays := HolidaysInGregorianYear(2022)
+	holidays = append(holidays, AnniversariesInRange([]Anniversary{
+		{"Yahrzeit of Sarah, née Levi; beloved mother", HebrewDate{5750, 5, 3}, true},
+	}, 738000, 739000)...)
+	opts := ICalendarOptions{
+		Name:      "Holidays",
+		Domain:    "example.org",
+		Calendars: []string{"hebrew", "islamic", "french"},
+		Stamp:     time.Date(2022, 6, 15, 12, 0, 0, 0, time.UTC),
+	}
+	var b strings.Builder
+	if err := WriteICalendar(&b, holidays, opts); err != nil {
+		t.Fatal(err)
+	}
+	s := b.String()
+
+	var tests = []struct {
+		name string
+		want string
+	}{
+		{"header", "BEGIN:VCALENDAR\r\nVERSION:2.0\r\nPRODID:-//staudtlex.de//libcalendar//EN\r\nCALSCALE:GREGORIAN\r\nX-WR-CALNAME:Holidays\r\n"},
+		{"event", "DTSTAMP:20220615T120000Z\r\nDTSTART;VALUE=DATE:20220416\r\nDTEND;VALUE=DATE:20220417\r\nSUMMARY:Passover\r\n"},
+		{"description", "DESCRIPTION:hebrew: 15 Nisan 5782\\nislamic: 14 Ramadan 1443\\nfrench: 27 Ger\r\n minal an 230\r\n"},
+		{"escaping", "SUMMARY:Yahrzeit of Sarah\\, née Levi\\; beloved mother\r\n"},
+		{"end", "END:VEVENT\r\nEND:VCALENDAR\r\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Logf("got %q, want %q", s, tt.want)
+			if !strings.Contains(s, tt.want) {
+				t.Errorf("got %q, want %q", s, tt.want)
+			}
+		})
+	}
+
+	t.Run("lines", func(t *testing.T) {
+		for _, l := range strings.Split(strings.TrimSuffix(s, "\r\n"), "\r\n") {
+			if len(l) > 75 || strings.ContainsAny(l, "\r\n") {
+				t.Errorf("invalid line %q", l)
+			}
+		}
+	})
+
+	t.Run("uid", func(t *testing.T) {
+		var again strings.Builder
+		opts.Stamp = time.Now()
+		WriteICalendar(&again, holidays[:1], opts)
+		uid := func(s string) string {
+			start := strings.Index(s, "UID:")
+			return s[start : start+strings.Index(s[start:], "\r\n")]
+		}
+		t.Logf("got %v, want %v", uid(again.String()), uid(s))
+		if uid(again.String()) != uid(s) || !strings.HasSuffix(uid(s), "@example.org") {
+			t.Errorf("got %v, want %v", uid(again.String()), uid(s))
+		}
+		if strings.Count(s, uid(s)+"\r\n") != 1 {
+			t.Errorf("UID %v is not unique", uid(s))
+		}
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		ans, err := ParseICalendar(strings.NewReader(s))
+		t.Logf("got %v, want %v", ans, holidays)
+		if err != nil || fmt.Sprint(ans) != fmt.Sprint(holidays) {
+			t.Errorf("got %v (%v), want %v", ans, err, holidays)
+		}
+	})
+}
+
+func TestParseICalendar(t *testing.T) {
+	var tests = []struct {
+		input string
+		want  []Holiday
+		err   error
+	}{
+		{"BEGIN:VCALENDAR\nBEGIN:VEVENT\nSUMMARY:Bastille\n  Day\nDTSTART:20220714T000000Z\nEND:VEVENT\nEND:VCALENDAR\n",
+			[]Holiday{{"Bastille Day", 738350}}, nil},
+		{"BEGIN:VCALENDAR\r\nBEGIN:VTODO\r\nSUMMARY:Other\r\nEND:VTODO\r\nEND:VCALENDAR\r\n", []Holiday{}, nil},
+		{"BEGIN:VEVENT\nEND:VEVENT\n", nil, ErrInvalidICalendar},
+		{"BEGIN:VCALENDAR\nBEGIN:VEVENT\nSUMMARY:Undated\nEND:VEVENT\nEND:VCALENDAR\n", nil, ErrInvalidICalendar},
+		{"BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART:2022\nEND:VEVENT\nEND:VCALENDAR\n", nil, ErrInvalidICalendar},
+		{"BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART:20220714\n", nil, ErrInvalidICalendar},
+	}
+	for i, tt := range tests {
+		testname := fmt.Sprint(i)
+		t.Run(testname, func(t *testing.T) {
+			ans, err := ParseICalendar(strings.NewReader(tt.input))
+			t.Logf("got %v, want %v", ans, tt.want)
+			if !errors.Is(err, tt.err) || fmt.Sprint(ans) != fmt.Sprint(tt.want) {
+				t.Errorf("got %v (%v), want %v (%v)", ans, err, tt.want, tt.err)
+			}
+		})
+	}
+}
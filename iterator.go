@@ -0,0 +1,329 @@
+// Copyright (C) 2022  Alexander Staudt
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// This file implements iterators over ranges of dates, and over the days
+// and months of non-Gregorian calendars. Each iterator is available as an
+// iter.Seq and in a callback form, which stops as soon as the callback
+// returns false.
+
+package libcalendar
+
+import (
+	"iter"
+	"math"
+	"math/big"
+)
+
+// Days returns an iterator over the absolute dates from `from` through `to`.
+func Days(from, to float64) iter.Seq[float64] {
+	return func(yield func(float64) bool) {
+		for d := from; d <= to; d++ {
+			if !yield(d) {
+				return
+			}
+		}
+	}
+}
+
+// EachDay calls f for each absolute date from `from` through `to`.
+func EachDay(from, to float64, f func(absoluteDate float64) bool) {
+	Days(from, to)(f)
+}
+
+// Dates returns an iterator over the dates from absolute date `from` through
+// absolute date `to`, represented in a given calendar.
+func Dates(from, to float64, calendar string) iter.Seq[Date] {
+	return func(yield func(Date) bool) {
+		for d := from; d <= to; d++ {
+			if !yield(DateFromAbsolute(d, calendar)) {
+				return
+			}
+		}
+	}
+}
+
+// EachDate calls f for each date from absolute date `from` through absolute
+// date `to`, represented in a given calendar.
+func EachDate(from, to float64, calendar string, f func(Date) bool) {
+	Dates(from, to, calendar)(f)
+}
+
+// calendarPeriod returns a key identifying the month (or, if yearly is true,
+// the year) an absolute date belongs to in a given calendar.
+func calendarPeriod(absoluteDate float64, calendar string, yearly bool) [3]float64 {
+	if calendar == "roman" {
+		// Roman days are named after the next reference day, but belong to
+		// the months of the Julian calendar
+		calendar = "julian"
+	}
+	if calendar == "oldHinduLunar" {
+		d := OldHinduLunarFromAbsolute(absoluteDate)
+		if yearly {
+			return [3]float64{d.Year}
+		}
+		leap := 0.0
+		if d.LeapMonth {
+			leap = 1
+		}
+		return [3]float64{d.Year, d.Month, leap}
+	}
+	if calendar == "hinduLunar" {
+		d := HinduLunarFromAbsolute(absoluteDate)
+		if yearly {
+			return [3]float64{d.Year}
+		}
+		return [3]float64{d.Year, d.Month, flag(d.LeapMonth)}
+	}
+	d := DateFromAbsolute(absoluteDate, calendar)
+	year, ok := d.Year()
+	switch {
+	case !ok || len(d.Components) < 2:
+		return [3]float64{}
+	case yearly:
+		return [3]float64{year}
+	default:
+		if _, month, _, ok := d.dayMonthYear(); ok {
+			return [3]float64{year, month}
+		}
+		return [3]float64{year, d.Components[1]}
+	}
+}
+
+// periodStarts returns an iterator over the absolute dates in [from, to] on
+// which a new month or year begins in a given calendar.
+func periodStarts(from, to float64, calendar string, yearly bool) iter.Seq[float64] {
+	return func(yield func(float64) bool) {
+		previous := calendarPeriod(from-1, calendar, yearly)
+		for d := from; d <= to; d++ {
+			current := calendarPeriod(d, calendar, yearly)
+			if current != previous && !yield(d) {
+				return
+			}
+			previous = current
+		}
+	}
+}
+
+// MonthStarts returns an iterator over the absolute dates from `from` through
+// `to` on which a month of a given calendar begins. Calendars without months
+// (ISO, Mayan, Aztec, Pawukon) yield
+// nothing.
+func MonthStarts(from, to float64, calendar string) iter.Seq[float64] {
+	switch calendar {
+	case "iso", "mayanLongCount", "mayanHaab", "mayanTzolkin", "aztecXihuitl", "aztecTonalpohualli", "baliPawukon":
+		return func(func(float64) bool) {}
+	}
+	return periodStarts(from, to, calendar, false)
+}
+
+// EachMonthStart calls f for each absolute date from `from` through `to` on
+// which a month of a given calendar begins.
+func EachMonthStart(from, to float64, calendar string, f func(absoluteDate float64) bool) {
+	MonthStarts(from, to, calendar)(f)
+}
+
+// YearStarts returns an iterator over the absolute dates from `from` through
+// `to` on which a year of a given calendar begins. The Mayan, Aztec and
+// Pawukon calendars yield nothing.
+func YearStarts(from, to float64, calendar string) iter.Seq[float64] {
+	switch calendar {
+	case "mayanLongCount", "mayanHaab", "mayanTzolkin", "aztecXihuitl", "aztecTonalpohualli", "baliPawukon":
+		return func(func(float64) bool) {}
+	}
+	return periodStarts(from, to, calendar, true)
+}
+
+// EachYearStart calls f for each absolute date from `from` through `to` on
+// which a year of a given calendar begins.
+func EachYearStart(from, to float64, calendar string, f func(absoluteDate float64) bool) {
+	YearStarts(from, to, calendar)(f)
+}
+
+// Hebrew calendar
+
+// HebrewMonthDays returns an iterator over the days of a given Hebrew month.
+func HebrewMonthDays(year, month float64) iter.Seq[HebrewDate] {
+	return func(yield func(HebrewDate) bool) {
+		for day := 1.0; day <= LastDayOfHebrewMonth(month, year); day++ {
+			if !yield(HebrewDate{year, month, day}) {
+				return
+			}
+		}
+	}
+}
+
+// EachHebrewMonthDay calls f for each day of a given Hebrew month.
+func EachHebrewMonthDay(year, month float64, f func(HebrewDate) bool) {
+	HebrewMonthDays(year, month)(f)
+}
+
+// HebrewMonths returns an iterator over the first days of the months of a
+// given Hebrew year, in chronological order from Tishri to Elul. In leap
+// years, Adar I (month 12) is followed by Adar II (month 13).
+func HebrewMonths(year float64) iter.Seq[HebrewDate] {
+	return func(yield func(HebrewDate) bool) {
+		months := LastMonthOfHebrewYear(year)
+		for i := 0.0; i < months; i++ {
+			month, _ := hebrewMonthFromIndex(hebrewMonthsBefore(year) + i)
+			if !yield(HebrewDate{year, month, 1}) {
+				return
+			}
+		}
+	}
+}
+
+// EachHebrewMonth calls f for the first day of each month of a given Hebrew
+// year.
+func EachHebrewMonth(year float64, f func(HebrewDate) bool) {
+	HebrewMonths(year)(f)
+}
+
+// Islamic calendar
+
+// IslamicMonthDays returns an iterator over the days of a given Islamic
+// month.
+func IslamicMonthDays(year, month float64) iter.Seq[IslamicDate] {
+	return func(yield func(IslamicDate) bool) {
+		for day := 1.0; day <= LastDayOfIslamicMonth(month, year); day++ {
+			if !yield(IslamicDate{year, month, day}) {
+				return
+			}
+		}
+	}
+}
+
+// EachIslamicMonthDay calls f for each day of a given Islamic month.
+func EachIslamicMonthDay(year, month float64, f func(IslamicDate) bool) {
+	IslamicMonthDays(year, month)(f)
+}
+
+// IslamicMonths returns an iterator over the first days of the months of a
+// given Islamic year.
+func IslamicMonths(year float64) iter.Seq[IslamicDate] {
+	return func(yield func(IslamicDate) bool) {
+		for month := 1.0; month <= 12; month++ {
+			if !yield(IslamicDate{year, month, 1}) {
+				return
+			}
+		}
+	}
+}
+
+// EachIslamicMonth calls f for the first day of each month of a given
+// Islamic year.
+func EachIslamicMonth(year float64, f func(IslamicDate) bool) {
+	IslamicMonths(year)(f)
+}
+
+// French Revolutionary calendar
+
+// FrenchMonthDays returns an iterator over the days of a given French
+// Revolutionary month. Month 13 denotes the sansculottides.
+func FrenchMonthDays(year, month float64) iter.Seq[FrenchDate] {
+	return func(yield func(FrenchDate) bool) {
+		for day := 1.0; day <= FrenchLastDayOfMonth(month, year); day++ {
+			if !yield(FrenchDate{year, month, day}) {
+				return
+			}
+		}
+	}
+}
+
+// EachFrenchMonthDay calls f for each day of a given French Revolutionary
+// month.
+func EachFrenchMonthDay(year, month float64, f func(FrenchDate) bool) {
+	FrenchMonthDays(year, month)(f)
+}
+
+// FrenchMonths returns an iterator over the first days of the months of a
+// given French Revolutionary year, including the sansculottides (month 13).
+func FrenchMonths(year float64) iter.Seq[FrenchDate] {
+	return func(yield func(FrenchDate) bool) {
+		for month := 1.0; month <= 13; month++ {
+			if !yield(FrenchDate{year, month, 1}) {
+				return
+			}
+		}
+	}
+}
+
+// EachFrenchMonth calls f for the first day of each month of a given French
+// Revolutionary year.
+func EachFrenchMonth(year float64, f func(FrenchDate) bool) {
+	FrenchMonths(year)(f)
+}
+
+// Old Hindu lunar calendar
+
+// OldHinduLunarMonthDays returns an iterator over the days of a given Old
+// Hindu lunar month. Expunged days are skipped.
+func OldHinduLunarMonthDays(year, month float64, leapMonth bool) iter.Seq[OldHinduLunarDate] {
+	return func(yield func(OldHinduLunarDate) bool) {
+		first := math.NaN()
+		for day := 1.0; day <= 30 && math.IsNaN(first); day++ {
+			first = AbsoluteFromOldHinduLunar(OldHinduLunarDate{year, month, leapMonth, day})
+		}
+		if math.IsNaN(first) {
+			return
+		}
+		for date := first; ; date++ {
+			d := OldHinduLunarFromAbsolute(date)
+			if d.Year != year || d.Month != month || d.LeapMonth != leapMonth {
+				return
+			}
+			if !yield(d) {
+				return
+			}
+		}
+	}
+}
+
+// EachOldHinduLunarMonthDay calls f for each day of a given Old Hindu lunar
+// month.
+func EachOldHinduLunarMonthDay(year, month float64, leapMonth bool, f func(OldHinduLunarDate) bool) {
+	OldHinduLunarMonthDays(year, month, leapMonth)(f)
+}
+
+// OldHinduLunarMonths returns an iterator over the first days of the months
+// of a given Old Hindu lunar year, in chronological order. A leap month
+// (LeapMonth == true) precedes the ordinary month of the same name. If the
+// first day of a month is expunged, its earliest day is yielded instead.
+func OldHinduLunarMonths(year float64) iter.Seq[OldHinduLunarDate] {
+	return func(yield func(OldHinduLunarDate) bool) {
+		// start two months before the mean beginning of the solar year
+		approx := sub(mult(big.NewRat(int64(year), 1), SolarSiderealYear), mult(big.NewRat(2, 1), LunarSynodicMonth))
+		for newMoon := NewMoon(approx); ; newMoon = add(newMoon, LunarSynodicMonth) {
+			month := oldHinduLunarMonthOf(newMoon)
+			if month.Year < year {
+				continue
+			}
+			if month.Year > year {
+				return
+			}
+			for d := range OldHinduLunarMonthDays(month.Year, month.Month, month.LeapMonth) {
+				if !yield(d) {
+					return
+				}
+				break
+			}
+		}
+	}
+}
+
+// EachOldHinduLunarMonth calls f for the first day of each month of a given
+// Old Hindu lunar year.
+func EachOldHinduLunarMonth(year float64, f func(OldHinduLunarDate) bool) {
+	OldHinduLunarMonths(year)(f)
+}
@@ -0,0 +1,123 @@
+// Copyright (C) 2022  Alexander Staudt
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package libcalendar
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestDays(t *testing.T) {
+	n := 0.0
+	for d := range Days(738321, 738330) {
+		if d != 738321+n {
+			t.Errorf("got %v, want %v", d, 738321+n)
+		}
+		n++
+	}
+	if n != 10 {
+		t.Errorf("got %v days, want 10", n)
+	}
+	// the callback form stops early
+	n = 0
+	EachDay(738321, 738330, func(float64) bool { n++; return n < 3 })
+	if n != 3 {
+		t.Errorf("got %v days, want 3", n)
+	}
+}
+
+func TestHebrewMonths(t *testing.T) {
+	tests := []struct {
+		year float64
+		want []float64
+	}{
+		{5783, []float64{7, 8, 9, 10, 11, 12, 1, 2, 3, 4, 5, 6}},
+		{5784, []float64{7, 8, 9, 10, 11, 12, 13, 1, 2, 3, 4, 5, 6}},
+	}
+
+	for _, tt := range tests {
+		testname := fmt.Sprintf("%.0f", tt.year)
+		t.Run(testname, func(t *testing.T) {
+			got := []float64{}
+			days := 0.0
+			for d := range HebrewMonths(tt.year) {
+				got = append(got, d.Month)
+				for range HebrewMonthDays(d.Year, d.Month) {
+					days++
+				}
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			if days != DaysInHebrewYear(tt.year) {
+				t.Errorf("got %v days, want %v", days, DaysInHebrewYear(tt.year))
+			}
+		})
+	}
+}
+
+func TestMonthStarts(t *testing.T) {
+	from := AbsoluteFromHebrew(HebrewDate{5784, tishri, 1})
+	to := AbsoluteFromHebrew(HebrewDate{5785, tishri, 1}) - 1
+	i := 0
+	for d := range MonthStarts(from, to, "hebrew") {
+		month, _ := hebrewMonthFromIndex(hebrewMonthsBefore(5784) + float64(i))
+		want := AbsoluteFromHebrew(HebrewDate{5784, month, 1})
+		if d != want {
+			t.Errorf("got %v, want %v", d, want)
+		}
+		i++
+	}
+	if i != 13 {
+		t.Errorf("got %v months, want 13", i)
+	}
+}
+
+func TestBahaiYearStarts(t *testing.T) {
+	from := AbsoluteFromGregorian(GregorianDate{2022, 1, 1})
+	to := AbsoluteFromGregorian(GregorianDate{2022, 12, 31})
+	for _, calendar := range []string{"bahai", "westernBahai"} {
+		var got []float64
+		for d := range YearStarts(from, to, calendar) {
+			got = append(got, d)
+		}
+		want := []float64{AbsoluteFromDate(DateFromComponents(calendar, []float64{179, 1, 1}))}
+		t.Logf("got %v, want %v", got, want)
+		if !equal(got, want) {
+			t.Errorf("%v: got %v, want %v", calendar, got, want)
+		}
+	}
+}
+
+func TestOldHinduLunarMonths(t *testing.T) {
+	// find a year with a leap month
+	for year := 5100.0; year < 5110; year++ {
+		months := []OldHinduLunarDate{}
+		for d := range OldHinduLunarMonths(year) {
+			months = append(months, d)
+		}
+		leap := false
+		for i, m := range months {
+			leap = leap || m.LeapMonth
+			if i > 0 && AbsoluteFromOldHinduLunar(m) <= AbsoluteFromOldHinduLunar(months[i-1]) {
+				t.Errorf("%.0f: months out of order: %v", year, months)
+			}
+		}
+		if (leap && len(months) != 13) || (!leap && len(months) != 12) {
+			t.Errorf("%.0f: got %v months (leap: %v)", year, len(months), leap)
+		}
+	}
+}
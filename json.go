@@ -21,6 +21,7 @@ package libcalendar
 import (
 	"bufio"
 	"bytes"
+	"encoding/json"
 	"io"
 	"strconv"
 	"strings"
@@ -70,6 +71,12 @@ func isDigit(ch rune) bool {
 	return ch >= '0' && ch <= '9'
 }
 
+// isNumber returns true if a rune may be part of a JSON number, and false
+// otherwise.
+func isNumber(ch rune) bool {
+	return isDigit(ch) || ch == '-' || ch == '+' || ch == '.' || ch == 'e' || ch == 'E'
+}
+
 // Scanner represents a lexical scanner.
 type Scanner struct {
 	reader *bufio.Reader
@@ -130,7 +137,7 @@ func (s *Scanner) scanArray() (tok token, str string) {
 	var buf bytes.Buffer
 	s.skipWhitespace()
 	for {
-		if ch := s.read(); ch == ']' {
+		if ch := s.read(); ch == ']' || ch == eof {
 			break
 		} else {
 			if ch != '\t' && ch != '\n' {
@@ -141,13 +148,14 @@ func (s *Scanner) scanArray() (tok token, str string) {
 	return ARRAY, buf.String()
 }
 
-// scanDigits returns a token representing digits.
+// scanDigits returns a token representing a number, possibly negative or
+// fractional.
 func (s *Scanner) scanDigits() (tok token, str string) {
 	var buf bytes.Buffer
 	for {
 		if ch := s.read(); ch == eof {
 			break
-		} else if !isDigit(ch) {
+		} else if !isNumber(ch) {
 			s.unread()
 			break
 		} else {
@@ -196,7 +204,7 @@ func (s *Scanner) Scan() (tok token, str string) {
 	} else if isLetter(ch) {
 		s.unread()
 		return s.scanName()
-	} else if isDigit(ch) {
+	} else if isDigit(ch) || ch == '-' {
 		s.unread()
 		return s.scanDigits()
 	}
@@ -218,12 +226,12 @@ type tokenString struct {
 }
 
 // tokenize tokenizes a string and returns all elements representing either a
-// JSON key, string value, or array.
+// JSON key, string value, number, or array.
 func tokenize(s string) []tokenString {
 	scan := NewScanner(strings.NewReader(s))
 	tokens := []tokenString{}
 	for tok, str := scan.Scan(); tok != EOF; tok, str = scan.Scan() {
-		if tok == NAME_OR_STRING || tok == CALENDAR || tok == COMPONENTS || tok == ARRAY {
+		if tok == NAME_OR_STRING || tok == CALENDAR || tok == COMPONENTS || tok == ARRAY || tok == DIGITS {
 			tokens = append(tokens, tokenString{tok, str})
 		}
 	}
@@ -247,30 +255,30 @@ func parseNumArray(s string) []float64 {
 // isValidCalendar returns true if the given string matches the name of a
 // supported calendar, and false otherwise.
 func isValidCalendar(calendar string) bool {
-	switch calendar {
-	case "gregorian",
-		"julian",
-		"iso",
-		"islamic",
-		"hebrew",
-		"mayanLongCount",
-		"mayanHaab",
-		"mayanTzolkin",
-		"french",
-		"oldHinduSolar",
-		"oldHinduLunar":
-		return true
-	default:
-		return false
+	for _, c := range Calendars() {
+		if c == calendar {
+			return true
+		}
 	}
+	return false
 }
 
 // JsonToDate unmarshals a JSON-serialized Date object into a Date struct.
-// Unmarshals only elements "calendar" and "components".
-func JsonToDate(json string) Date {
-	tokens := tokenize(json)
+// Unmarshals only elements "calendar" and "components". If the JSON does not
+// contain a supported calendar or is not valid JSON, the Calendar of the
+// result is empty.
+func JsonToDate(s string) Date {
+	if !json.Valid([]byte(s)) {
+		return Date{}
+	}
+	tokens := tokenize(s)
 	data := map[token]string{}
 	for i := 0; i < len(tokens); i++ {
+		// keys without a value, e.g. in {"note":"calendar"} or
+		// {"calendar":{}}, are ignored
+		if i+1 == len(tokens) {
+			break
+		}
 		switch {
 		case tokens[i].token == CALENDAR:
 			if isValidCalendar(tokens[i+1].string) {
@@ -0,0 +1,364 @@
+// Copyright (C) 2022  Alexander Staudt
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// This file implements a small locale layer for month names and date
+// layouts. Locales are identified by BCP 47-like tags such as "de", "he" or
+// "pt-BR". Names missing from a locale are looked up along its fallback
+// chain, which always ends in the root locale "en".
+
+package libcalendar
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+// RootLocale is the tag of the locale every fallback chain ends in. Its names
+// are those used by the String() methods in format.go.
+const RootLocale = "en"
+
+// Locale holds localized names and layouts for a language. All maps are
+// keyed by calendar name, as used in FromAbsolute (e.g. "hebrew").
+type Locale struct {
+	Tag        string                        // e.g. "de", "he", "pt-BR"
+	Parent     string                        // consulted before the language and root locales, may be empty
+	Months     map[string]map[float64]string // month names
+	LeapMonths map[string]map[float64]string // month names replacing Months in leap years
+	Weekdays   map[string]map[float64]string // names of the days of the week, see LocalizedWeekdayName
+	Layouts    map[string]string             // date layouts, see FormatOptions
+}
+
+var (
+	localesMu sync.RWMutex
+	locales   = map[string]Locale{}
+)
+
+// RegisterLocale adds a locale to the registry, replacing any locale
+// previously registered under the same tag. Applications may use it to
+// provide additional locales or to override the built-in ones.
+func RegisterLocale(l Locale) {
+	localesMu.Lock()
+	defer localesMu.Unlock()
+	locales[l.Tag] = l
+}
+
+// LookupLocale returns the locale registered under tag, and whether such a
+// locale exists.
+func LookupLocale(tag string) (Locale, bool) {
+	localesMu.RLock()
+	defer localesMu.RUnlock()
+	l, ok := locales[tag]
+	return l, ok
+}
+
+// Locales returns the sorted tags of all registered locales.
+func Locales() []string {
+	localesMu.RLock()
+	defer localesMu.RUnlock()
+	tags := make([]string, 0, len(locales))
+	for tag := range locales {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// LocaleFallbacks returns the fallback chain of a given tag: the tag itself,
+// the chain of Parent locales, the language subtag (e.g. "de" for "de-AT"),
+// and finally the root locale.
+func LocaleFallbacks(tag string) []string {
+	chain := []string{}
+	seen := map[string]bool{}
+	push := func(t string) {
+		if t != "" && !seen[t] {
+			seen[t] = true
+			chain = append(chain, t)
+		}
+	}
+	for t := tag; t != "" && !seen[t]; {
+		push(t)
+		l, ok := LookupLocale(t)
+		if !ok {
+			break
+		}
+		t = l.Parent
+	}
+	if i := strings.IndexAny(tag, "-_"); i > 0 {
+		push(tag[:i])
+	}
+	push(RootLocale)
+	return chain
+}
+
+// lookupName returns the first name found for a given key along the fallback
+// chain of tag.
+func lookupName(tag string, key float64, table func(Locale) map[float64]string) (string, bool) {
+	for _, t := range LocaleFallbacks(tag) {
+		l, ok := LookupLocale(t)
+		if !ok {
+			continue
+		}
+		if name, ok := table(l)[key]; ok {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// monthCalendar returns the calendar whose month names are used for a given
+// calendar. Julian months share the Gregorian names.
+func monthCalendar(calendar string) string {
+	switch calendar {
+	case "julian":
+		return "gregorian"
+	case "hinduSolar":
+		return "oldHinduSolar"
+	case "hinduLunar":
+		return "oldHinduLunar"
+	case "westernBahai":
+		return "bahai"
+	default:
+		return calendar
+	}
+}
+
+// isLeapYear reports whether a given year of a calendar uses leap month
+// names.
+func isLeapYear(calendar string, year float64) bool {
+	switch calendar {
+	case "hebrew":
+		return HebrewLeapYear(year)
+	default:
+		return false
+	}
+}
+
+// LocalizedMonthName returns the name of a given month of a given year in the
+// calendar and locale specified. It returns an empty string if the calendar
+// has no such month.
+func LocalizedMonthName(calendar string, month, year float64, tag string) string {
+	calendar = monthCalendar(calendar)
+	if isLeapYear(calendar, year) {
+		name, ok := lookupName(tag, month, func(l Locale) map[float64]string {
+			return l.LeapMonths[calendar]
+		})
+		if ok {
+			return name
+		}
+	}
+	name, _ := lookupName(tag, month, func(l Locale) map[float64]string {
+		return l.Months[calendar]
+	})
+	return name
+}
+
+// LocalizedMonthNames returns all month names of a given year in the calendar
+// and locale specified, ordered by month number.
+func LocalizedMonthNames(calendar string, year float64, tag string) []string {
+	root, _ := LookupLocale(RootLocale)
+	months := keys(root.Months[monthCalendar(calendar)])
+	if isLeapYear(monthCalendar(calendar), year) {
+		for _, m := range keys(root.LeapMonths[monthCalendar(calendar)]) {
+			if !member(m, months) {
+				months = append(months, m)
+			}
+		}
+	}
+	names := make([]string, 0, len(months))
+	for _, m := range months {
+		names = append(names, LocalizedMonthName(calendar, m, year, tag))
+	}
+	return names
+}
+
+// FormatOptions control how dates are rendered by Format.
+//
+// Layouts are strings in which "{day}", "{month}" and "{year}" are replaced
+// by the date's day, month name and year, e.g. "{day} {month} {year}". For
+// the Mayan tzolkin, "{day}" denotes the number and "{month}" the name.
+//
+// Days and years are written in the numeral system given by Numerals, unless
+// YearNumerals is set, which then applies to years. For instance, Hebrew dates
+// may be written in gematria (HebrewNumerals), and French Revolutionary years
+// in Roman numerals (YearNumerals: RomanNumerals, giving "an VIII").
+type FormatOptions struct {
+	Locale       string        // locale tag, defaults to RootLocale
+	Layout       string        // overrides the locale's layout, if not empty
+	Numerals     NumeralSystem // numerals of days and years
+	YearNumerals NumeralSystem // numerals of years, overrides Numerals
+}
+
+// yearNumerals returns the numeral system used for years.
+func (o FormatOptions) yearNumerals() NumeralSystem {
+	if o.YearNumerals != DefaultNumerals {
+		return o.YearNumerals
+	}
+	return o.Numerals
+}
+
+// layout returns the date layout for a given calendar and options.
+func (o FormatOptions) layout(calendar string) string {
+	if o.Layout != "" {
+		return o.Layout
+	}
+	// calendar-specific layouts take precedence over a locale's default
+	// layout (stored under the empty key)
+	for _, key := range []string{calendar, ""} {
+		for _, t := range LocaleFallbacks(o.Locale) {
+			l, ok := LookupLocale(t)
+			if !ok {
+				continue
+			}
+			if layout, ok := l.Layouts[key]; ok {
+				return layout
+			}
+		}
+	}
+	return "{day} {month} {year}"
+}
+
+// dayMonthYear returns the day, month and year components of a Date. For
+// calendars without years, year is 0.
+func (d Date) dayMonthYear() (day, month, year float64, ok bool) {
+	switch d.Calendar {
+	case "gregorian", "julian", "islamic", "hebrew", "french", "egyptian", "armenian",
+		"oldHinduSolar", "oldHinduLunar", "hinduSolar", "hinduLunar":
+		if len(d.Components) < 3 {
+			return 0, 0, 0, false
+		}
+		return d.Components[2], d.Components[1], d.Components[0], true
+	case "bahai", "westernBahai":
+		if len(d.Components) != 3 && len(d.Components) != 5 {
+			return 0, 0, 0, false
+		}
+		b := bahaiFromDate(d)
+		return b.Day, b.Month, b.BahaiYear(), true
+	case "mayanHaab", "mayanTzolkin", "aztecXihuitl", "aztecTonalpohualli":
+		if len(d.Components) < 2 {
+			return 0, 0, 0, false
+		}
+		return d.Components[0], d.Components[1], 0, true
+	default:
+		return 0, 0, 0, false
+	}
+}
+
+// Localize returns a copy of its receiver whose MonthNames and Weekday are
+// given in the locale specified.
+func (d Date) Localize(tag string) Date {
+	year := 0.0
+	if _, _, y, ok := d.dayMonthYear(); ok {
+		year = y
+	}
+	if names := LocalizedMonthNames(d.Calendar, year, tag); len(names) > 0 {
+		d.MonthNames = names
+	}
+	if d.Weekday != "" {
+		if name := LocalizedWeekdayName(d.Calendar, AbsoluteFromDate(d), tag); name != "" {
+			d.Weekday = name
+		}
+	}
+	return d
+}
+
+// Format returns a string representation of its receiver using the locale
+// and layout given in opts. Calendars without month names (ISO, Mayan long
+// count) are formatted as by String().
+func (d Date) Format(opts FormatOptions) string {
+	day, month, year, ok := d.dayMonthYear()
+	if !ok {
+		return d.String()
+	}
+	tag := opts.Locale
+	if tag == "" {
+		tag = RootLocale
+	}
+	dayText := FormatNumeral(day, opts.Numerals)
+	monthName := LocalizedMonthName(d.Calendar, month, year, tag)
+	// leap (adhika) months and days of the Hindu lunar calendar are marked
+	// as by String
+	if d.Calendar == "hinduLunar" && len(d.Components) == 5 {
+		if d.Components[3] == 1 {
+			monthName = "Adhika " + monthName
+		}
+		if d.Components[4] == 1 {
+			dayText += " (adhika)"
+		}
+	}
+	r := strings.NewReplacer(
+		"{day}", dayText,
+		"{month}", monthName,
+		"{year}", FormatNumeral(year, opts.yearNumerals()),
+	)
+	return r.Replace(opts.layout(d.Calendar))
+}
+
+// FormatFromAbsolute returns a string-formatted calendar date from a given
+// absolute date and calendar name, using the locale and layout given in opts.
+func FormatFromAbsolute(absoluteDate float64, calendar string, opts FormatOptions) string {
+	return DateFromAbsolute(absoluteDate, calendar).Format(opts)
+}
+
+// Format returns a localized string representation of its receiver.
+func (d GregorianDate) Format(opts FormatOptions) string { return d.Date().Format(opts) }
+
+// Format returns a localized string representation of its receiver.
+func (d JulianDate) Format(opts FormatOptions) string { return d.Date().Format(opts) }
+
+// Format returns a localized string representation of its receiver.
+func (d IslamicDate) Format(opts FormatOptions) string { return d.Date().Format(opts) }
+
+// Format returns a localized string representation of its receiver.
+func (d HebrewDate) Format(opts FormatOptions) string { return d.Date().Format(opts) }
+
+// Format returns a localized string representation of its receiver.
+func (d MayanHaabDate) Format(opts FormatOptions) string { return d.Date().Format(opts) }
+
+// Format returns a localized string representation of its receiver.
+func (d MayanTzolkinDate) Format(opts FormatOptions) string { return d.Date().Format(opts) }
+
+// Format returns a localized string representation of its receiver.
+func (d AztecXihuitlDate) Format(opts FormatOptions) string { return d.Date().Format(opts) }
+
+// Format returns a localized string representation of its receiver.
+func (d AztecTonalpohualliDate) Format(opts FormatOptions) string { return d.Date().Format(opts) }
+
+// Format returns a localized string representation of its receiver.
+func (d FrenchDate) Format(opts FormatOptions) string { return d.Date().Format(opts) }
+
+// Format returns a localized string representation of its receiver.
+func (d RomanDate) Format(opts FormatOptions) string { return d.Date().Format(opts) }
+
+// Format returns a localized string representation of its receiver.
+func (d EgyptianDate) Format(opts FormatOptions) string { return d.Date().Format(opts) }
+
+// Format returns a localized string representation of its receiver.
+func (d ArmenianDate) Format(opts FormatOptions) string { return d.Date().Format(opts) }
+
+// Format returns a localized string representation of its receiver.
+func (d OldHinduSolarDate) Format(opts FormatOptions) string { return d.Date().Format(opts) }
+
+// Format returns a localized string representation of its receiver.
+func (d OldHinduLunarDate) Format(opts FormatOptions) string { return d.Date().Format(opts) }
+
+// Format returns a localized string representation of its receiver.
+func (d HinduSolarDate) Format(opts FormatOptions) string { return d.Date().Format(opts) }
+
+// Format returns a localized string representation of its receiver.
+func (d HinduLunarDate) Format(opts FormatOptions) string { return d.Date().Format(opts) }
+
+// Format returns a localized string representation of its receiver.
+func (d BahaiDate) Format(opts FormatOptions) string { return d.Date().Format(opts) }
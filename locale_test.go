@@ -0,0 +1,147 @@
+// Copyright (C) 2022  Alexander Staudt
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package libcalendar
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestFormatRootLocaleMatchesString(t *testing.T) {
+	for i, rd := range dates.Rd {
+		tests := []struct {
+			got  string
+			want string
+		}{
+			{dates.Gregorian[i].Format(FormatOptions{}), dates.Gregorian[i].String()},
+			{dates.Islamic[i].Format(FormatOptions{}), dates.Islamic[i].String()},
+			// String keeps the padding of "Adar " in format.go
+			{dates.Hebrew[i].Format(FormatOptions{}), strings.Replace(dates.Hebrew[i].String(), "Adar  ", "Adar ", 1)},
+			{dates.French[i].Format(FormatOptions{}), dates.French[i].String()},
+			{dates.MayanHaab[i].Format(FormatOptions{}), dates.MayanHaab[i].String()},
+			{dates.MayanTzolkin[i].Format(FormatOptions{}), dates.MayanTzolkin[i].String()},
+		}
+		for _, tt := range tests {
+			testname := fmt.Sprintf("%.0f", rd)
+			t.Run(testname, func(t *testing.T) {
+				if tt.got != tt.want {
+					t.Errorf("got %v, want %v", tt.got, tt.want)
+				}
+			})
+		}
+	}
+}
+
+func TestLocalizedMonthName(t *testing.T) {
+	tests := []struct {
+		calendar string
+		month    float64
+		year     float64
+		tag      string
+		want     string
+	}{
+		{"gregorian", 3, 2022, "de", "März"},
+		{"gregorian", 3, 2022, "de-AT", "März"},
+		{"julian", 8, 1500, "fr", "août"},
+		{"hebrew", 7, 5783, "he", "תשרי"},
+		{"hebrew", 12, 5783, "he", "אדר"},
+		{"hebrew", 12, 5784, "he", "אדר א׳"},
+		{"hebrew", 13, 5784, "he", "אדר ב׳"},
+		{"hebrew", 12, 5784, "de", "Adar I"},
+		{"hebrew", 13, 5784, "de", "Adar II"},
+		{"hebrew", 13, 5784, "es", "Adar II"}, // falls back to the root locale
+		{"islamic", 9, 1444, "ar", "رمضان"},
+		{"oldHinduLunar", 1, 5123, "hi", "चैत्र"},
+		{"mayanHaab", 18, 0, "zh", "Cumku"},
+	}
+
+	for _, tt := range tests {
+		testname := fmt.Sprintf("%s %.0f %.0f %s", tt.calendar, tt.month, tt.year, tt.tag)
+		t.Run(testname, func(t *testing.T) {
+			got := LocalizedMonthName(tt.calendar, tt.month, tt.year, tt.tag)
+			t.Logf("got %v, want %v", got, tt.want)
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegisterLocale(t *testing.T) {
+	t.Cleanup(func() {
+		localesMu.Lock()
+		defer localesMu.Unlock()
+		delete(locales, "de-CH")
+	})
+	RegisterLocale(Locale{
+		Tag:    "de-CH",
+		Parent: "de",
+		Months: map[string]map[float64]string{
+			"gregorian": {3: "Maerz"},
+		},
+	})
+	tests := []struct {
+		date GregorianDate
+		tag  string
+		want string
+	}{
+		{GregorianDate{2022, 3, 1}, "de-CH", "1. Maerz 2022"},
+		{GregorianDate{2022, 4, 1}, "de-CH", "1. April 2022"},
+		{GregorianDate{2022, 6, 15}, "zh", "2022年六月15日"},
+		{GregorianDate{2022, 6, 15}, "xx", "15 June 2022"},
+	}
+
+	for _, tt := range tests {
+		testname := fmt.Sprintf("%v %s", tt.date, tt.tag)
+		t.Run(testname, func(t *testing.T) {
+			got := tt.date.Format(FormatOptions{Locale: tt.tag})
+			t.Logf("got %v, want %v", got, tt.want)
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatAdar(t *testing.T) {
+	ans := HebrewDate{5783, 12, 14}.Format(FormatOptions{})
+	want := "14 Adar 5783"
+	t.Logf("got %v, want %v", ans, want)
+	if ans != want {
+		t.Errorf("got %v, want %v", ans, want)
+	}
+}
+
+func TestLookupLocaleCopies(t *testing.T) {
+	en, _ := LookupLocale("en")
+	name := en.Months["gregorian"][6]
+	en.Months["gregorian"][6] = "Juno"
+	t.Cleanup(func() { en.Months["gregorian"][6] = name })
+	if gregorianMonths[6] != "June" {
+		t.Errorf("got %v, want %v", gregorianMonths[6], "June")
+	}
+}
+
+func TestDateLocalize(t *testing.T) {
+	d := HebrewDate{5784, 13, 14}.Date().Localize("he")
+	if len(d.MonthNames) != 13 {
+		t.Fatalf("got %v month names, want 13", len(d.MonthNames))
+	}
+	if d.MonthNames[12] != "אדר ב׳" {
+		t.Errorf("got %v, want %v", d.MonthNames[12], "אדר ב׳")
+	}
+}
@@ -0,0 +1,354 @@
+// Copyright (C) 2022  Alexander Staudt
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// This file contains the built-in locales.
+
+package libcalendar
+
+import "strings"
+
+func init() {
+	RegisterLocale(localeEn)
+	RegisterLocale(localeDe)
+	RegisterLocale(localeFr)
+	RegisterLocale(localeEs)
+	RegisterLocale(localeIt)
+	RegisterLocale(localePt)
+	RegisterLocale(localeRu)
+	RegisterLocale(localeHe)
+	RegisterLocale(localeAr)
+	RegisterLocale(localeHi)
+	RegisterLocale(localeZh)
+}
+
+// cloneNames returns a copy of a table of names of format.go, without the
+// padding some of them carry (e.g. "Adar "), so that changes to a registered
+// locale do not alter the default names.
+func cloneNames(names map[float64]string) map[float64]string {
+	c := make(map[float64]string, len(names))
+	for k, v := range names {
+		c[k] = strings.TrimSpace(v)
+	}
+	return c
+}
+
+// English (root locale), using the names of format.go
+var localeEn = Locale{
+	Tag: "en",
+	Months: map[string]map[float64]string{
+		"gregorian":          cloneNames(gregorianMonths),
+		"islamic":            cloneNames(islamicMonths),
+		"hebrew":             cloneNames(hebrewMonths),
+		"mayanHaab":          cloneNames(mayanHaabMonths),
+		"mayanTzolkin":       cloneNames(mayanTzolkinNames),
+		"aztecXihuitl":       cloneNames(aztecXihuitlMonths),
+		"aztecTonalpohualli": cloneNames(aztecTonalpohualliNames),
+		"french":             cloneNames(frenchMonths),
+		"egyptian":           cloneNames(egyptianMonths),
+		"armenian":           cloneNames(armenianMonths),
+		"oldHinduSolar":      cloneNames(hinduSolarMonths),
+		"oldHinduLunar":      cloneNames(hinduLunarMonths),
+		"bahai":              cloneNames(bahaiMonths),
+	},
+	LeapMonths: map[string]map[float64]string{
+		"hebrew": {12: "Adar I", 13: "Adar II"},
+	},
+	Weekdays: map[string]map[float64]string{
+		"gregorian":            cloneNames(gregorianWeekdays),
+		"hebrew":               cloneNames(hebrewWeekdays),
+		"islamic":              cloneNames(islamicWeekdays),
+		"roman":                cloneNames(romanWeekdays),
+		"hindu":                cloneNames(hinduWeekdays),
+		"bahai":                cloneNames(bahaiWeekdays),
+		"french":               cloneNames(frenchDecadeDays),
+		"frenchSansculottides": cloneNames(frenchSansculottides),
+	},
+	Layouts: map[string]string{
+		"":                   "{day} {month} {year}",
+		"french":             "{day} {month} an {year}",
+		"mayanHaab":          "{day} {month}",
+		"mayanTzolkin":       "{day} {month}",
+		"aztecXihuitl":       "{day} {month}",
+		"aztecTonalpohualli": "{day} {month}",
+	},
+}
+
+// German
+var localeDe = Locale{
+	Tag: "de",
+	Months: map[string]map[float64]string{
+		"gregorian": {
+			1: "Januar", 2: "Februar", 3: "März", 4: "April",
+			5: "Mai", 6: "Juni", 7: "Juli", 8: "August",
+			9: "September", 10: "Oktober", 11: "November", 12: "Dezember",
+		},
+		"islamic": {
+			1: "Muharram", 2: "Safar", 3: "Rabi' al-awwal", 4: "Rabi' ath-thani",
+			5: "Dschumada l-ula", 6: "Dschumada th-thaniya", 7: "Radschab", 8: "Scha'ban",
+			9: "Ramadan", 10: "Schawwal", 11: "Dhu l-qa'da", 12: "Dhu l-hiddscha",
+		},
+		"hebrew": {
+			1: "Nisan", 2: "Ijjar", 3: "Siwan", 4: "Tammus", 5: "Aw", 6: "Elul",
+			7: "Tischri", 8: "Cheschwan", 9: "Kislew", 10: "Tevet", 11: "Schevat",
+			12: "Adar",
+		},
+	},
+	LeapMonths: map[string]map[float64]string{
+		"hebrew": {12: "Adar I", 13: "Adar II"},
+	},
+	Weekdays: map[string]map[float64]string{
+		"gregorian": {
+			0: "Sonntag", 1: "Montag", 2: "Dienstag", 3: "Mittwoch",
+			4: "Donnerstag", 5: "Freitag", 6: "Samstag",
+		},
+	},
+	Layouts: map[string]string{
+		"": "{day}. {month} {year}",
+	},
+}
+
+// French
+var localeFr = Locale{
+	Tag: "fr",
+	Months: map[string]map[float64]string{
+		"gregorian": {
+			1: "janvier", 2: "février", 3: "mars", 4: "avril",
+			5: "mai", 6: "juin", 7: "juillet", 8: "août",
+			9: "septembre", 10: "octobre", 11: "novembre", 12: "décembre",
+		},
+		"islamic": {
+			1: "mouharram", 2: "safar", 3: "rabia al awal", 4: "rabia ath-thani",
+			5: "joumada al oula", 6: "joumada ath-thania", 7: "rajab", 8: "chaabane",
+			9: "ramadan", 10: "chawwal", 11: "dhou al qi`da", 12: "dhou al-hijja",
+		},
+		"hebrew": {
+			1: "nissan", 2: "iyar", 3: "sivan", 4: "tamouz", 5: "av", 6: "eloul",
+			7: "tichri", 8: "hechvan", 9: "kislev", 10: "tevet", 11: "chevat",
+			12: "adar",
+		},
+		"french": {
+			1: "vendémiaire", 2: "brumaire", 3: "frimaire", 4: "nivôse",
+			5: "pluviôse", 6: "ventôse", 7: "germinal", 8: "floréal",
+			9: "prairial", 10: "messidor", 11: "thermidor", 12: "fructidor",
+			13: "jours complémentaires",
+		},
+	},
+	LeapMonths: map[string]map[float64]string{
+		"hebrew": {12: "adar I", 13: "adar II"},
+	},
+	Weekdays: map[string]map[float64]string{
+		"gregorian": {
+			0: "dimanche", 1: "lundi", 2: "mardi", 3: "mercredi",
+			4: "jeudi", 5: "vendredi", 6: "samedi",
+		},
+		"french": {
+			1: "primidi", 2: "duodi", 3: "tridi", 4: "quartidi", 5: "quintidi",
+			6: "sextidi", 7: "septidi", 8: "octidi", 9: "nonidi", 10: "décadi",
+		},
+		"frenchSansculottides": {
+			1: "jour de la vertu", 2: "jour du génie", 3: "jour du travail",
+			4: "jour de l'opinion", 5: "jour des récompenses", 6: "jour de la révolution",
+		},
+	},
+}
+
+// Spanish
+var localeEs = Locale{
+	Tag: "es",
+	Months: map[string]map[float64]string{
+		"gregorian": {
+			1: "enero", 2: "febrero", 3: "marzo", 4: "abril",
+			5: "mayo", 6: "junio", 7: "julio", 8: "agosto",
+			9: "septiembre", 10: "octubre", 11: "noviembre", 12: "diciembre",
+		},
+	},
+	Weekdays: map[string]map[float64]string{
+		"gregorian": {
+			0: "domingo", 1: "lunes", 2: "martes", 3: "miércoles",
+			4: "jueves", 5: "viernes", 6: "sábado",
+		},
+	},
+	Layouts: map[string]string{
+		"": "{day} de {month} de {year}",
+	},
+}
+
+// Italian
+var localeIt = Locale{
+	Tag: "it",
+	Months: map[string]map[float64]string{
+		"gregorian": {
+			1: "gennaio", 2: "febbraio", 3: "marzo", 4: "aprile",
+			5: "maggio", 6: "giugno", 7: "luglio", 8: "agosto",
+			9: "settembre", 10: "ottobre", 11: "novembre", 12: "dicembre",
+		},
+	},
+	Weekdays: map[string]map[float64]string{
+		"gregorian": {
+			0: "domenica", 1: "lunedì", 2: "martedì", 3: "mercoledì",
+			4: "giovedì", 5: "venerdì", 6: "sabato",
+		},
+	},
+}
+
+// Portuguese
+var localePt = Locale{
+	Tag: "pt",
+	Months: map[string]map[float64]string{
+		"gregorian": {
+			1: "janeiro", 2: "fevereiro", 3: "março", 4: "abril",
+			5: "maio", 6: "junho", 7: "julho", 8: "agosto",
+			9: "setembro", 10: "outubro", 11: "novembro", 12: "dezembro",
+		},
+	},
+	Weekdays: map[string]map[float64]string{
+		"gregorian": {
+			0: "domingo", 1: "segunda-feira", 2: "terça-feira", 3: "quarta-feira",
+			4: "quinta-feira", 5: "sexta-feira", 6: "sábado",
+		},
+	},
+	Layouts: map[string]string{
+		"": "{day} de {month} de {year}",
+	},
+}
+
+// Russian (month names in the genitive, as used in dates)
+var localeRu = Locale{
+	Tag: "ru",
+	Months: map[string]map[float64]string{
+		"gregorian": {
+			1: "января", 2: "февраля", 3: "марта", 4: "апреля",
+			5: "мая", 6: "июня", 7: "июля", 8: "августа",
+			9: "сентября", 10: "октября", 11: "ноября", 12: "декабря",
+		},
+	},
+	Weekdays: map[string]map[float64]string{
+		"gregorian": {
+			0: "воскресенье", 1: "понедельник", 2: "вторник", 3: "среда",
+			4: "четверг", 5: "пятница", 6: "суббота",
+		},
+	},
+}
+
+// Hebrew
+var localeHe = Locale{
+	Tag: "he",
+	Months: map[string]map[float64]string{
+		"gregorian": {
+			1: "ינואר", 2: "פברואר", 3: "מרץ", 4: "אפריל",
+			5: "מאי", 6: "יוני", 7: "יולי", 8: "אוגוסט",
+			9: "ספטמבר", 10: "אוקטובר", 11: "נובמבר", 12: "דצמבר",
+		},
+		"hebrew": {
+			1: "ניסן", 2: "אייר", 3: "סיון", 4: "תמוז", 5: "אב", 6: "אלול",
+			7: "תשרי", 8: "חשון", 9: "כסלו", 10: "טבת", 11: "שבט", 12: "אדר",
+		},
+	},
+	LeapMonths: map[string]map[float64]string{
+		"hebrew": {12: "אדר א׳", 13: "אדר ב׳"},
+	},
+	Weekdays: map[string]map[float64]string{
+		"gregorian": {
+			0: "יום ראשון", 1: "יום שני", 2: "יום שלישי", 3: "יום רביעי",
+			4: "יום חמישי", 5: "יום שישי", 6: "שבת",
+		},
+		"hebrew": {
+			0: "יום ראשון", 1: "יום שני", 2: "יום שלישי", 3: "יום רביעי",
+			4: "יום חמישי", 5: "יום שישי", 6: "שבת",
+		},
+	},
+	Layouts: map[string]string{
+		"hebrew": "{day} ב{month} {year}",
+	},
+}
+
+// Arabic
+var localeAr = Locale{
+	Tag: "ar",
+	Months: map[string]map[float64]string{
+		"gregorian": {
+			1: "يناير", 2: "فبراير", 3: "مارس", 4: "أبريل",
+			5: "مايو", 6: "يونيو", 7: "يوليو", 8: "أغسطس",
+			9: "سبتمبر", 10: "أكتوبر", 11: "نوفمبر", 12: "ديسمبر",
+		},
+		"islamic": {
+			1: "محرم", 2: "صفر", 3: "ربيع الأول", 4: "ربيع الآخر",
+			5: "جمادى الأولى", 6: "جمادى الآخرة", 7: "رجب", 8: "شعبان",
+			9: "رمضان", 10: "شوال", 11: "ذو القعدة", 12: "ذو الحجة",
+		},
+	},
+	Weekdays: map[string]map[float64]string{
+		"gregorian": {
+			0: "الأحد", 1: "الاثنين", 2: "الثلاثاء", 3: "الأربعاء",
+			4: "الخميس", 5: "الجمعة", 6: "السبت",
+		},
+		"islamic": {
+			0: "الأحد", 1: "الاثنين", 2: "الثلاثاء", 3: "الأربعاء",
+			4: "الخميس", 5: "الجمعة", 6: "السبت",
+		},
+	},
+}
+
+// Hindi
+var localeHi = Locale{
+	Tag: "hi",
+	Months: map[string]map[float64]string{
+		"gregorian": {
+			1: "जनवरी", 2: "फ़रवरी", 3: "मार्च", 4: "अप्रैल",
+			5: "मई", 6: "जून", 7: "जुलाई", 8: "अगस्त",
+			9: "सितंबर", 10: "अक्टूबर", 11: "नवंबर", 12: "दिसंबर",
+		},
+		"oldHinduSolar": {
+			1: "मेष", 2: "वृषभ", 3: "मिथुन", 4: "कर्क", 5: "सिंह", 6: "कन्या",
+			7: "तुला", 8: "वृश्चिक", 9: "धनु", 10: "मकर", 11: "कुम्भ", 12: "मीन",
+		},
+		"oldHinduLunar": {
+			1: "चैत्र", 2: "वैशाख", 3: "ज्येष्ठ", 4: "आषाढ़", 5: "श्रावण", 6: "भाद्रपद",
+			7: "आश्विन", 8: "कार्तिक", 9: "मार्गशीर्ष", 10: "पौष", 11: "माघ", 12: "फाल्गुन",
+		},
+	},
+	Weekdays: map[string]map[float64]string{
+		"gregorian": {
+			0: "रविवार", 1: "सोमवार", 2: "मंगलवार", 3: "बुधवार",
+			4: "गुरुवार", 5: "शुक्रवार", 6: "शनिवार",
+		},
+		"hindu": {
+			0: "रविवार", 1: "सोमवार", 2: "मंगलवार", 3: "बुधवार",
+			4: "गुरुवार", 5: "शुक्रवार", 6: "शनिवार",
+		},
+	},
+}
+
+// Chinese
+var localeZh = Locale{
+	Tag: "zh",
+	Months: map[string]map[float64]string{
+		"gregorian": {
+			1: "一月", 2: "二月", 3: "三月", 4: "四月",
+			5: "五月", 6: "六月", 7: "七月", 8: "八月",
+			9: "九月", 10: "十月", 11: "十一月", 12: "十二月",
+		},
+	},
+	Weekdays: map[string]map[float64]string{
+		"gregorian": {
+			0: "星期日", 1: "星期一", 2: "星期二", 3: "星期三",
+			4: "星期四", 5: "星期五", 6: "星期六",
+		},
+	},
+	Layouts: map[string]string{
+		"gregorian": "{year}年{month}{day}日",
+		"julian":    "{year}年{month}{day}日",
+	},
+}
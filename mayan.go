@@ -0,0 +1,167 @@
+// Copyright (C) 2022  Alexander Staudt
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// This file implements Mayan calendar computations beyond the conversions in
+// calendar.go: correlations of the long count with absolute dates other than
+// the Goodman-Martinez-Thompson correlation, the nine Lords of the Night, the
+// 819-day count, and the search for Calendar Round dates.
+
+package libcalendar
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// MayanCorrelation is the Julian day number of the beginning of the Mayan
+// long count (0.0.0.0.0, or 13.0.0.0.0 4 Ahau 8 Cumku), which fixes the
+// correlation of Mayan and European dates.
+type MayanCorrelation float64
+
+// Correlations of the Mayan long count
+const (
+	MayanCorrelationGMT       MayanCorrelation = 584283 // Goodman-Martinez-Thompson, as used by the Mayan calendar functions
+	MayanCorrelationLounsbury MayanCorrelation = 584285 // "GMT+2", after Lounsbury
+	MayanCorrelationSpinden   MayanCorrelation = 489384
+)
+
+// DaysBeforeAbsoluteZero returns the number of days of the Mayan calendar
+// epoch before absolute day 0 according to its receiver.
+func (c MayanCorrelation) DaysBeforeAbsoluteZero() float64 {
+	return -(float64(c) + julianDayEpoch - 0.5)
+}
+
+// ErrImpossibleCalendarRound is returned for combinations of a haab and a
+// tzolkin date that never fall on the same day.
+var ErrImpossibleCalendarRound = errors.New("libcalendar: impossible calendar round")
+
+// MayanCalendar converts between absolute dates and Mayan dates according to
+// a correlation. The zero value uses the Goodman-Martinez-Thompson
+// correlation, as do the Mayan calendar functions of this package;
+// NewMayanCalendar sets any other correlation.
+type MayanCalendar struct {
+	shift float64 // days to add to an absolute date for the GMT correlation
+}
+
+// NewMayanCalendar returns a MayanCalendar using a given correlation.
+func NewMayanCalendar(c MayanCorrelation) MayanCalendar {
+	return MayanCalendar{c.DaysBeforeAbsoluteZero() - MayanDaysBeforeAbsoluteZero}
+}
+
+// Correlation returns the correlation of m.
+func (m MayanCalendar) Correlation() MayanCorrelation {
+	return MayanCorrelation(-(MayanDaysBeforeAbsoluteZero + m.shift) - julianDayEpoch + 0.5)
+}
+
+// LongCountFromAbsolute returns the Mayan long count of a given absolute date.
+func (m MayanCalendar) LongCountFromAbsolute(absoluteDate float64) MayanLongCount {
+	return MayanLongCountFromAbsolute(absoluteDate + m.shift)
+}
+
+// AbsoluteFromLongCount returns the absolute date of a given Mayan long count.
+func (m MayanCalendar) AbsoluteFromLongCount(d MayanLongCount) (absoluteDate float64) {
+	return AbsoluteFromMayanLongCount(d) - m.shift
+}
+
+// HaabFromAbsolute returns the Mayan haab date of a given absolute date.
+func (m MayanCalendar) HaabFromAbsolute(absoluteDate float64) MayanHaabDate {
+	return MayanHaabFromAbsolute(absoluteDate + m.shift)
+}
+
+// TzolkinFromAbsolute returns the Mayan tzolkin date of a given absolute date.
+func (m MayanCalendar) TzolkinFromAbsolute(absoluteDate float64) MayanTzolkinDate {
+	return MayanTzolkinFromAbsolute(absoluteDate + m.shift)
+}
+
+// LordOfTheNight returns the number (1 to 9) of the Lord of the Night, G1 to
+// G9, presiding over a given absolute date. The long count began with G9.
+func (m MayanCalendar) LordOfTheNight(absoluteDate float64) float64 {
+	return amod(absoluteDate+m.shift+MayanDaysBeforeAbsoluteZero, 9)
+}
+
+// Count819 returns the position (0 to 818) of a given absolute date in the
+// 819-day count, i.e. the number of days since the last station of the
+// count. The stations fall on the days 3 + 819k of the long count.
+func (m MayanCalendar) Count819(absoluteDate float64) float64 {
+	return mod(absoluteDate+m.shift+MayanDaysBeforeAbsoluteZero-3, 819)
+}
+
+// validCalendarRound checks the components of a haab and a tzolkin date.
+func validCalendarRound(haab MayanHaabDate, tzolkin MayanTzolkinDate) bool {
+	for _, x := range []float64{haab.Day, haab.Month, tzolkin.Number, tzolkin.Name} {
+		if x != math.Floor(x) {
+			return false
+		}
+	}
+	return haab.Day >= 0 && haab.Day <= 19 && haab.Month >= 1 && haab.Month <= 19 &&
+		!(haab.Month == 19 && haab.Day > 4) &&
+		tzolkin.Number >= 1 && tzolkin.Number <= 13 && tzolkin.Name >= 1 && tzolkin.Name <= 20
+}
+
+// CalendarRoundOnOrBefore returns the latest absolute date on or before d
+// with a given haab and tzolkin date. Only a quarter of all combinations
+// occur; for the others, it returns ErrImpossibleCalendarRound.
+func (m MayanCalendar) CalendarRoundOnOrBefore(haab MayanHaabDate, tzolkin MayanTzolkinDate, d float64) (absoluteDate float64, err error) {
+	if !validCalendarRound(haab, tzolkin) {
+		return math.NaN(), fmt.Errorf("%w: %v %v", ErrInvalidDate, tzolkin, haab)
+	}
+	haabDifference := MayanHaabDifference(m.HaabFromAbsolute(0), haab)
+	tzolkinDifference := MayanTzolkinDifference(m.TzolkinFromAbsolute(0), tzolkin)
+	difference := tzolkinDifference - haabDifference
+	if mod(difference, 5) != 0 {
+		return math.NaN(), fmt.Errorf("%w: %v %v", ErrImpossibleCalendarRound, tzolkin, haab)
+	}
+	return d - mod(d-(haabDifference+(365*difference)), 18980), nil
+}
+
+// CalendarRoundDates returns the absolute dates from absolute date `from`
+// through absolute date `to` with a given haab and tzolkin date, which recur
+// every 18980 days (52 haab years).
+func (m MayanCalendar) CalendarRoundDates(haab MayanHaabDate, tzolkin MayanTzolkinDate, from, to float64) ([]float64, error) {
+	last, err := m.CalendarRoundOnOrBefore(haab, tzolkin, to)
+	if err != nil {
+		return nil, err
+	}
+	absoluteDates := []float64{}
+	for d := last - 18980*math.Floor((last-from)/18980); d <= last; d += 18980 {
+		absoluteDates = append(absoluteDates, d)
+	}
+	return absoluteDates, nil
+}
+
+// MayanLordOfTheNight returns the number (1 to 9) of the Lord of the Night
+// presiding over a given absolute date, see MayanCalendar.LordOfTheNight.
+func MayanLordOfTheNight(absoluteDate float64) float64 {
+	return MayanCalendar{}.LordOfTheNight(absoluteDate)
+}
+
+// Mayan819Count returns the position of a given absolute date in the 819-day
+// count, see MayanCalendar.Count819.
+func Mayan819Count(absoluteDate float64) float64 {
+	return MayanCalendar{}.Count819(absoluteDate)
+}
+
+// MayanCalendarRoundOnOrBefore returns the latest absolute date on or before d
+// with a given haab and tzolkin date, see MayanCalendar.CalendarRoundOnOrBefore.
+func MayanCalendarRoundOnOrBefore(haab MayanHaabDate, tzolkin MayanTzolkinDate, d float64) (absoluteDate float64, err error) {
+	return MayanCalendar{}.CalendarRoundOnOrBefore(haab, tzolkin, d)
+}
+
+// MayanCalendarRoundDates returns the absolute dates in a range with a given
+// haab and tzolkin date, see MayanCalendar.CalendarRoundDates.
+func MayanCalendarRoundDates(haab MayanHaabDate, tzolkin MayanTzolkinDate, from, to float64) ([]float64, error) {
+	return MayanCalendar{}.CalendarRoundDates(haab, tzolkin, from, to)
+}
@@ -0,0 +1,185 @@
+// Copyright (C) 2022  Alexander Staudt
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package libcalendar
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestMayanCalendar(t *testing.T) {
+	rd := AbsoluteFromGregorian(GregorianDate{2012, 12, 21})
+	var tests = []struct {
+		correlation MayanCorrelation
+		longCount   MayanLongCount
+		haab        MayanHaabDate
+		tzolkin     MayanTzolkinDate
+		lord        float64
+	}{
+		{0, MayanLongCount{13, 0, 0, 0, 0}, MayanHaabDate{3, kankin}, MayanTzolkinDate{4, ahau}, 9},
+		{MayanCorrelationGMT, MayanLongCount{13, 0, 0, 0, 0}, MayanHaabDate{3, kankin}, MayanTzolkinDate{4, ahau}, 9},
+		{MayanCorrelationLounsbury, MayanLongCount{12, 19, 19, 17, 18}, MayanHaabDate{1, kankin}, MayanTzolkinDate{2, etznab}, 7},
+		{MayanCorrelationSpinden, MayanLongCount{13, 13, 3, 10, 19}, MayanHaabDate{2, kankin}, MayanTzolkinDate{3, cauac}, 3},
+	}
+	for _, tt := range tests {
+		testname := fmt.Sprint(tt.correlation)
+		t.Run(testname, func(t *testing.T) {
+			m := MayanCalendar{}
+			if tt.correlation != 0 {
+				m = NewMayanCalendar(tt.correlation)
+			}
+			longCount, haab, tzolkin := m.LongCountFromAbsolute(rd), m.HaabFromAbsolute(rd), m.TzolkinFromAbsolute(rd)
+			lord := m.LordOfTheNight(rd)
+			t.Logf("got %v %v %v G%v, want %v %v %v G%v", longCount, tzolkin, haab, lord, tt.longCount, tt.tzolkin, tt.haab, tt.lord)
+			if longCount != tt.longCount || haab != tt.haab || tzolkin != tt.tzolkin || lord != tt.lord ||
+				m.AbsoluteFromLongCount(longCount) != rd {
+				t.Errorf("got %v %v %v G%v, want %v %v %v G%v", longCount, tzolkin, haab, lord, tt.longCount, tt.tzolkin, tt.haab, tt.lord)
+			}
+		})
+	}
+
+	for _, c := range []MayanCorrelation{MayanCorrelationLounsbury, MayanCorrelationSpinden, 0} {
+		if got := NewMayanCalendar(c).Correlation(); got != c {
+			t.Errorf("got %v, want %v", got, c)
+		}
+	}
+	if got := (MayanCalendar{}).Correlation(); got != MayanCorrelationGMT {
+		t.Errorf("got %v, want %v", got, MayanCorrelationGMT)
+	}
+}
+
+func TestMayan819Count(t *testing.T) {
+	var tests = []struct {
+		absoluteDate float64
+		want         float64
+	}{
+		{-MayanDaysBeforeAbsoluteZero, 816},
+		{-MayanDaysBeforeAbsoluteZero + 3, 0},
+		{-MayanDaysBeforeAbsoluteZero + 3 + 819*1700, 0},
+		{AbsoluteFromGregorian(GregorianDate{2012, 12, 21}), 582},
+	}
+	for _, tt := range tests {
+		testname := fmt.Sprint(tt.absoluteDate)
+		t.Run(testname, func(t *testing.T) {
+			ans := Mayan819Count(tt.absoluteDate)
+			t.Logf("got %v, want %v", ans, tt.want)
+			if ans != tt.want {
+				t.Errorf("got %v, want %v", ans, tt.want)
+			}
+		})
+	}
+}
+
+func TestMayanCalendarRoundDates(t *testing.T) {
+	var tests = []struct {
+		haab     MayanHaabDate
+		tzolkin  MayanTzolkinDate
+		from, to float64
+		want     []float64
+		err      error
+	}{
+		{MayanHaabDate{3, kankin}, MayanTzolkinDate{4, ahau}, 700000, 760000, []float64{715878, 734858, 753838}, nil},
+		{MayanHaabDate{3, kankin}, MayanTzolkinDate{4, ahau}, 734858, 734858, []float64{734858}, nil},
+		{MayanHaabDate{3, kankin}, MayanTzolkinDate{4, ahau}, 734859, 753837, []float64{}, nil},
+		{MayanHaabDate{3, kankin}, MayanTzolkinDate{5, ahau}, 730000, 740000, []float64{730478}, nil},
+		{MayanHaabDate{4, kankin}, MayanTzolkinDate{4, ahau}, 700000, 760000, nil, ErrImpossibleCalendarRound},
+		{MayanHaabDate{5, 19}, MayanTzolkinDate{4, ahau}, 700000, 760000, nil, ErrInvalidDate},
+		{MayanHaabDate{3, kankin}, MayanTzolkinDate{14, ahau}, 700000, 760000, nil, ErrInvalidDate},
+	}
+	for _, tt := range tests {
+		testname := fmt.Sprintf("%v %v", tt.tzolkin, tt.haab)
+		t.Run(testname, func(t *testing.T) {
+			ans, err := MayanCalendarRoundDates(tt.haab, tt.tzolkin, tt.from, tt.to)
+			t.Logf("got %v (%v), want %v (%v)", ans, err, tt.want, tt.err)
+			if !errors.Is(err, tt.err) || fmt.Sprint(ans) != fmt.Sprint(tt.want) {
+				t.Errorf("got %v (%v), want %v (%v)", ans, err, tt.want, tt.err)
+			}
+		})
+	}
+
+	t.Run("on or before", func(t *testing.T) {
+		ans := MayanHaabTzolkinOnOrBefore(MayanHaabDate{3, kankin}, MayanTzolkinDate{4, ahau}, 740000)
+		haab := MayanHaabOnOrBefore(MayanHaabDate{3, kankin}, 740000)
+		t.Logf("got %v %v, want %v %v", ans, haab, 734858, 739968)
+		if ans != 734858 || haab != 739968 {
+			t.Errorf("got %v %v, want %v %v", ans, haab, 734858, 739968)
+		}
+	})
+}
+
+func TestMayanHaabDifference(t *testing.T) {
+	var tests = []struct {
+		d1, d2 MayanHaabDate
+		want   float64
+	}{
+		{MayanHaabDate{0, pop}, MayanHaabDate{0, pop}, 0},
+		{MayanHaabDate{0, pop}, MayanHaabDate{5, pop}, 5},
+		{MayanHaabDate{0, pop}, MayanHaabDate{0, uo}, 20},
+		{MayanHaabDate{0, pop}, MayanHaabDate{4, 19}, 364},
+		{MayanHaabDate{4, 19}, MayanHaabDate{0, pop}, 1},
+		// 13.0.0.0.0, 1872000 days after the epoch 8 Cumku, is 3 Kankin
+		{MayanHaabDate{8, cumku}, MayanHaabDate{3, kankin}, 280},
+	}
+	for _, tt := range tests {
+		testname := fmt.Sprintf("%v %v", tt.d1, tt.d2)
+		t.Run(testname, func(t *testing.T) {
+			ans := MayanHaabDifference(tt.d1, tt.d2)
+			t.Logf("got %v, want %v", ans, tt.want)
+			if ans != tt.want {
+				t.Errorf("got %v, want %v", ans, tt.want)
+			}
+		})
+	}
+}
+
+func TestMayanHaabOnOrBefore(t *testing.T) {
+	for _, d := range []float64{0, 730000, 738321} {
+		for rd := d; rd > d-365; rd-- {
+			haab := MayanHaabFromAbsolute(rd)
+			if ans := MayanHaabOnOrBefore(haab, d); ans != rd {
+				t.Errorf("%v on or before %v: got %v, want %v", haab, d, ans, rd)
+			}
+		}
+	}
+}
+
+// TestMayanHaabTzolkinOnOrBefore checks that MayanHaabTzolkinOnOrBefore
+// still returns the results of the formula of Reingold and Dershowitz, which
+// earlier versions used, given correct haab differences.
+func TestMayanHaabTzolkinOnOrBefore(t *testing.T) {
+	formula := func(haab MayanHaabDate, tzolkin MayanTzolkinDate, d float64) float64 {
+		haabDifference := MayanHaabDifference(MayanHaabFromAbsolute(0), haab)
+		tzolkinDifference := MayanTzolkinDifference(MayanTzolkinFromAbsolute(0), tzolkin)
+		difference := tzolkinDifference - haabDifference
+		if mod(difference, 5) != 0 {
+			return math.NaN()
+		}
+		return d - mod(d-(haabDifference+(365*difference)), 18980)
+	}
+	for rd := 700000.0; rd < 720000; rd += 7 {
+		haab := MayanHaabFromAbsolute(rd)
+		for _, tzolkin := range []MayanTzolkinDate{MayanTzolkinFromAbsolute(rd), MayanTzolkinFromAbsolute(rd + 1)} {
+			for _, d := range []float64{rd, 738321} {
+				ans, want := MayanHaabTzolkinOnOrBefore(haab, tzolkin, d), formula(haab, tzolkin, d)
+				if ans != want && !(math.IsNaN(ans) && math.IsNaN(want)) {
+					t.Errorf("%v %v on or before %v: got %v, want %v", tzolkin, haab, d, ans, want)
+				}
+			}
+		}
+	}
+}
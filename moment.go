@@ -0,0 +1,211 @@
+// Copyright (C) 2022  Alexander Staudt
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// This file implements moments, i.e. absolute dates with a time of day, and
+// conversions between universal, standard and local mean time at a location.
+
+package libcalendar
+
+import (
+	"math"
+	"math/big"
+	"time"
+)
+
+// Moment is an absolute (fixed) date with the fraction of the day elapsed
+// since midnight, e.g. 738321.75 for 18:00 on 15 June 2022. Unless stated
+// otherwise, moments are in universal time.
+type Moment float64
+
+// MomentFromAbsolute returns the moment of midnight beginning a given
+// absolute date.
+func MomentFromAbsolute(absoluteDate float64) Moment {
+	return Moment(absoluteDate)
+}
+
+// AbsoluteDate returns the absolute date containing the moment.
+func (m Moment) AbsoluteDate() float64 {
+	return math.Floor(float64(m))
+}
+
+// TimeOfDay returns the fraction of the day elapsed since midnight.
+func (m Moment) TimeOfDay() float64 {
+	return mod(float64(m), 1)
+}
+
+// MomentFromTime returns the universal moment of a given time.
+func MomentFromTime(t time.Time) Moment {
+	seconds := float64(t.Unix()) + float64(t.Nanosecond())/1e9
+	return Moment(unixDayEpoch + seconds/86400)
+}
+
+// Time returns the moment as a time in UTC, rounded to the microsecond.
+func (m Moment) Time() time.Time {
+	seconds := (float64(m) - unixDayEpoch) * 86400
+	whole := math.Floor(seconds)
+	micro := math.Round((seconds - whole) * 1e6)
+	return time.Unix(int64(whole), int64(micro)*1000).UTC()
+}
+
+// Location is a place on earth, given by its geographic coordinates and its
+// time zone.
+type Location struct {
+	Latitude  float64        // degrees north of the equator, negative for south
+	Longitude float64        // degrees east of Greenwich, negative for west
+	Elevation float64        // meters above sea level
+	Zone      float64        // offset of standard time from universal time in hours, used if TimeZone is nil
+	TimeZone  *time.Location // time zone, including daylight saving time
+}
+
+// LocalFromUniversal converts a universal moment into local mean time, i.e.
+// the time given by the mean position of the sun at the location.
+func LocalFromUniversal(m Moment, l Location) Moment {
+	return m + Moment(l.Longitude/360)
+}
+
+// UniversalFromLocal converts a moment in local mean time into universal
+// time.
+func UniversalFromLocal(m Moment, l Location) Moment {
+	return m - Moment(l.Longitude/360)
+}
+
+// StandardFromUniversal converts a universal moment into the standard (clock)
+// time of the location, including daylight saving time if the location has a
+// TimeZone.
+func StandardFromUniversal(m Moment, l Location) Moment {
+	if l.TimeZone == nil {
+		return m + Moment(l.Zone/24)
+	}
+	_, offset := m.Time().In(l.TimeZone).Zone()
+	return m + Moment(float64(offset)/86400)
+}
+
+// UniversalFromStandard converts a moment in the standard (clock) time of the
+// location into universal time. Clock times skipped or repeated by daylight
+// saving transitions are resolved as by time.Date.
+func UniversalFromStandard(m Moment, l Location) Moment {
+	if l.TimeZone == nil {
+		return m - Moment(l.Zone/24)
+	}
+	t := m.Time()
+	local := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), l.TimeZone)
+	return MomentFromTime(local)
+}
+
+// StandardFromLocal converts a moment in local mean time into the standard
+// time of the location.
+func StandardFromLocal(m Moment, l Location) Moment {
+	return StandardFromUniversal(UniversalFromLocal(m, l), l)
+}
+
+// LocalFromStandard converts a moment in the standard time of the location
+// into local mean time.
+func LocalFromStandard(m Moment, l Location) Moment {
+	return LocalFromUniversal(UniversalFromStandard(m, l), l)
+}
+
+// Mean sunrise and sunset, in local mean time, used by the Old Hindu
+// calendars and where the sun does not rise or set
+const (
+	meanSunrise = 0.25
+	meanSunset  = 0.75
+)
+
+// DayStart returns the moment, in the standard time of a location, at which
+// a given absolute date begins in a calendar: at sunset on the preceding day
+// for the Hebrew, Islamic and Bahá'í calendars, at sunrise for the Hindu
+// calendars, and at midnight otherwise. Where the sun does not rise or set,
+// 6:00 and 18:00 local mean time are used instead.
+func DayStart(calendar string, absoluteDate float64, l Location) Moment {
+	switch calendar {
+	case "hebrew", "islamic", "bahai", "westernBahai":
+		if sunset, ok := Sunset(absoluteDate-1, l); ok {
+			return sunset
+		}
+		return StandardFromLocal(Moment(absoluteDate-1+meanSunset), l)
+	case "oldHinduSolar", "oldHinduLunar", "hinduSolar", "hinduLunar":
+		if sunrise, ok := Sunrise(absoluteDate, l); ok {
+			return sunrise
+		}
+		return StandardFromLocal(Moment(absoluteDate+meanSunrise), l)
+	default:
+		return Moment(absoluteDate)
+	}
+}
+
+// AbsoluteFromMoment returns the absolute date of a calendar containing a
+// given universal moment at a location, taking into account when days begin
+// in that calendar (see DayStart). For instance, the evening of a Friday is
+// already Shabbat in the Hebrew calendar.
+func AbsoluteFromMoment(m Moment, calendar string, l Location) float64 {
+	standard := StandardFromUniversal(m, l)
+	date := standard.AbsoluteDate()
+	switch {
+	case standard >= DayStart(calendar, date+1, l):
+		return date + 1
+	case standard < DayStart(calendar, date, l):
+		return date - 1
+	default:
+		return date
+	}
+}
+
+// DateFromMoment converts a given universal moment at a location into the
+// date representation specified in `calendar` (see AbsoluteFromMoment).
+func DateFromMoment(m Moment, calendar string, l Location) Date {
+	return DateFromAbsolute(AbsoluteFromMoment(m, calendar, l), calendar)
+}
+
+// HebrewFromMoment returns the Hebrew date at a given universal moment and
+// location, the date changing at sunset.
+func HebrewFromMoment(m Moment, l Location) HebrewDate {
+	return HebrewFromAbsolute(AbsoluteFromMoment(m, "hebrew", l))
+}
+
+// IslamicFromMoment returns the Islamic date at a given universal moment and
+// location, the date changing at sunset.
+func IslamicFromMoment(m Moment, l Location) IslamicDate {
+	return IslamicFromAbsolute(AbsoluteFromMoment(m, "islamic", l))
+}
+
+// Old Hindu astronomy counts days and fractions of days from the beginning of
+// the Kali Yuga, 18 February 3102 B.C.E. (Julian), and takes sunrise to occur
+// at 6:00 mean time.
+const oldHinduEpoch = -1132959
+
+// OldHinduSunrise returns the moment of (mean) sunrise on a given absolute
+// date, as used by the Old Hindu calendars.
+func OldHinduSunrise(absoluteDate float64) Moment {
+	return Moment(absoluteDate + meanSunrise)
+}
+
+// oldHinduDays returns a moment as the rational number of days elapsed since
+// the Old Hindu epoch, as expected by SolarLongitude, LunarLongitude and
+// NewMoon.
+func oldHinduDays(m Moment) *big.Rat {
+	return add(new(big.Rat).SetFloat64(float64(m)), big.NewRat(-oldHinduEpoch, 1))
+}
+
+// momentFromOldHinduDays reverses oldHinduDays.
+func momentFromOldHinduDays(t *big.Rat) Moment {
+	days, _ := t.Float64()
+	return Moment(days + oldHinduEpoch)
+}
+
+// OldHinduNewMoon returns the moment of the most recent (mean) new moon at
+// or before a given moment, according to Old Hindu astronomy.
+func OldHinduNewMoon(m Moment) Moment {
+	return momentFromOldHinduDays(NewMoon(oldHinduDays(m)))
+}
@@ -0,0 +1,163 @@
+// Copyright (C) 2022  Alexander Staudt
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package libcalendar
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+func TestMomentTime(t *testing.T) {
+	var tests = []struct {
+		time time.Time
+		want Moment
+	}{
+		{time.Date(2022, 6, 15, 0, 0, 0, 0, time.UTC), 738321},
+		{time.Date(2022, 6, 15, 18, 0, 0, 0, time.UTC), 738321.75},
+		{time.Date(2022, 6, 15, 20, 0, 0, 0, time.FixedZone("EDT", -4*3600)), 738322},
+		{time.Date(1, 1, 1, 6, 0, 0, 0, time.UTC), 1.25},
+	}
+	for _, tt := range tests {
+		testname := tt.time.String()
+		t.Run(testname, func(t *testing.T) {
+			ans := MomentFromTime(tt.time)
+			t.Logf("got %v, want %v", ans, tt.want)
+			if ans != tt.want || !ans.Time().Equal(tt.time) {
+				t.Errorf("got %v (%v), want %v", ans, ans.Time(), tt.want)
+			}
+		})
+	}
+}
+
+func TestMomentConversions(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var tests = []struct {
+		name      string
+		universal Moment
+		location  Location
+		standard  Moment
+		local     Moment
+	}{
+		{"zone", 738321.5, Location{Longitude: 35.2, Zone: 2}, 738321.5 + 2.0/24, 738321.5 + 35.2/360},
+		{"summer time", 738321.5, Location{Longitude: -74, TimeZone: newYork}, 738321.5 - 4.0/24, 738321.5 - 74.0/360},
+		{"winter time", 738500.5, Location{Longitude: -74, TimeZone: newYork}, 738500.5 - 5.0/24, 738500.5 - 74.0/360},
+	}
+	near := func(a, b Moment) bool { return math.Abs(float64(a-b)) < 1e-9 }
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			standard := StandardFromUniversal(tt.universal, tt.location)
+			local := LocalFromUniversal(tt.universal, tt.location)
+			t.Logf("got %v, %v, want %v, %v", standard, local, tt.standard, tt.local)
+			if !near(standard, tt.standard) || !near(local, tt.local) {
+				t.Errorf("got %v, %v, want %v, %v", standard, local, tt.standard, tt.local)
+			}
+			if u := UniversalFromStandard(standard, tt.location); !near(u, tt.universal) {
+				t.Errorf("got %v, want %v", u, tt.universal)
+			}
+			if u := UniversalFromLocal(local, tt.location); !near(u, tt.universal) {
+				t.Errorf("got %v, want %v", u, tt.universal)
+			}
+			if s := StandardFromLocal(local, tt.location); !near(s, tt.standard) {
+				t.Errorf("got %v, want %v", s, tt.standard)
+			}
+		})
+	}
+}
+
+func TestAbsoluteFromMoment(t *testing.T) {
+	newYork, _ := time.LoadLocation("America/New_York")
+	location := Location{Latitude: 40.7, Longitude: -74, TimeZone: newYork}
+	at := func(year int, month time.Month, day, hour int) Moment {
+		return MomentFromTime(time.Date(year, month, day, hour, 0, 0, 0, newYork))
+	}
+	var tests = []struct {
+		moment   Moment
+		calendar string
+		want     string
+	}{
+		{at(2022, 6, 17, 12), "gregorian", "17 June 2022"},
+		{at(2022, 6, 17, 23), "gregorian", "17 June 2022"},
+		{at(2022, 6, 17, 12), "hebrew", "18 Sivan 5782"},
+		{at(2022, 6, 17, 20), "hebrew", "18 Sivan 5782"}, // sunset at 20:30
+		{at(2022, 6, 17, 21), "hebrew", "19 Sivan 5782"},
+		{at(2022, 6, 17, 21), "islamic", "18 Dhu al-Qada 1443"},
+		{at(2022, 6, 17, 5), "oldHinduSolar", fmt.Sprint(OldHinduSolarFromAbsolute(738322))},
+		{at(2022, 6, 17, 7), "oldHinduSolar", fmt.Sprint(OldHinduSolarFromAbsolute(738323))},
+	}
+	for _, tt := range tests {
+		testname := fmt.Sprintf("%v %v", tt.moment, tt.calendar)
+		t.Run(testname, func(t *testing.T) {
+			ans := fmt.Sprint(DateFromMoment(tt.moment, tt.calendar, location))
+			t.Logf("got %v, want %v", ans, tt.want)
+			if ans != tt.want {
+				t.Errorf("got %v, want %v", ans, tt.want)
+			}
+		})
+	}
+}
+
+func TestOldHinduNewMoon(t *testing.T) {
+	for _, date := range []float64{738321, 738322, 738350} {
+		testname := fmt.Sprint(date)
+		t.Run(testname, func(t *testing.T) {
+			ans := OldHinduNewMoon(OldHinduSunrise(date))
+			want := momentFromOldHinduDays(oldHinduLunarMonthStart(date))
+			t.Logf("got %v, want %v", ans, want)
+			if ans != want || ans > OldHinduSunrise(date) || OldHinduSunrise(date)-ans > 30 {
+				t.Errorf("got %v, want %v", ans, want)
+			}
+		})
+	}
+}
+
+func TestDayStart(t *testing.T) {
+	jerusalem, _ := time.LoadLocation("Asia/Jerusalem")
+	location := Location{Latitude: 31.7683, Longitude: 35.2137, TimeZone: jerusalem}
+	saturday := AbsoluteFromGregorian(GregorianDate{2022, 6, 18})
+	var tests = []struct {
+		name string
+		got  Moment
+		want string // standard time
+	}{
+		{"Shabbat begins", DayStart("hebrew", saturday, location), "2022-06-17 19:47"},
+		{"Shabbat ends", DayStart("hebrew", saturday+1, location), "2022-06-18 19:47"},
+		{"gregorian", DayStart("gregorian", saturday, location), "2022-06-18 00:00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ans := tt.got.Time().Round(time.Minute).Format("2006-01-02 15:04")
+			t.Logf("got %v, want %v", ans, tt.want)
+			if ans != tt.want {
+				t.Errorf("got %v, want %v", ans, tt.want)
+			}
+		})
+	}
+
+	t.Run("HebrewFromMoment", func(t *testing.T) {
+		evening := MomentFromTime(time.Date(2022, 6, 17, 20, 0, 0, 0, jerusalem))
+		ans, want := HebrewFromMoment(evening, location), HebrewFromAbsolute(saturday)
+		t.Logf("got %v, want %v", ans, want)
+		if ans != want || IslamicFromMoment(evening, location) != IslamicFromAbsolute(saturday) {
+			t.Errorf("got %v, want %v", ans, want)
+		}
+	})
+}
@@ -0,0 +1,289 @@
+// Copyright (C) 2022  Alexander Staudt
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package libcalendar
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// NumeralSystem denotes a system of writing numbers.
+type NumeralSystem int
+
+const (
+	DefaultNumerals       NumeralSystem = iota // Western digits, unless overridden
+	WesternNumerals                            // 0123456789
+	EasternArabicNumerals                      // ٠١٢٣٤٥٦٧٨٩
+	DevanagariNumerals                         // ०१२३४५६७८९
+	HebrewNumerals                             // gematria, e.g. ה׳תשפ״ג
+	RomanNumerals                              // e.g. VIII
+)
+
+// Geresh and gershayim, the punctuation marks of Hebrew numerals
+const (
+	geresh    = '׳'
+	gershayim = '״'
+)
+
+// zero digits of the positional numeral systems
+var zeroDigits = map[NumeralSystem]rune{
+	WesternNumerals:       '0',
+	EasternArabicNumerals: '٠',
+	DevanagariNumerals:    '०',
+}
+
+// Hebrew letters and their numerical values, in descending order
+var hebrewLetters = []struct {
+	value  float64
+	letter string
+}{
+	{400, "ת"}, {300, "ש"}, {200, "ר"}, {100, "ק"},
+	{90, "צ"}, {80, "פ"}, {70, "ע"}, {60, "ס"}, {50, "נ"},
+	{40, "מ"}, {30, "ל"}, {20, "כ"}, {10, "י"},
+	{9, "ט"}, {8, "ח"}, {7, "ז"}, {6, "ו"}, {5, "ה"},
+	{4, "ד"}, {3, "ג"}, {2, "ב"}, {1, "א"},
+}
+
+// Roman numerals and their values, in descending order
+var romanNumerals = []struct {
+	value   float64
+	numeral string
+}{
+	{1000, "M"}, {900, "CM"}, {500, "D"}, {400, "CD"},
+	{100, "C"}, {90, "XC"}, {50, "L"}, {40, "XL"},
+	{10, "X"}, {9, "IX"}, {5, "V"}, {4, "IV"}, {1, "I"},
+}
+
+// FormatNumeral returns the representation of n in a given numeral system.
+// Numbers that cannot be written in a system (e.g. zero, negative or
+// fractional numbers in Hebrew or Roman numerals) are written in Western
+// digits.
+func FormatNumeral(n float64, system NumeralSystem) string {
+	integer := n == math.Floor(n) && !math.IsInf(n, 0)
+	switch {
+	case system == HebrewNumerals && integer && n > 0:
+		return hebrewNumeral(n)
+	case system == RomanNumerals && integer && n > 0 && n < 4000:
+		return romanNumeral(n)
+	case system == EasternArabicNumerals || system == DevanagariNumerals:
+		zero := zeroDigits[system]
+		return strings.Map(func(r rune) rune {
+			if isDigit(r) {
+				return zero + (r - '0')
+			}
+			return r
+		}, fmt.Sprint(n))
+	default:
+		return fmt.Sprint(n)
+	}
+}
+
+// hebrewThousands is the word "thousands", following the thousands of
+// multiples of 1000 in Hebrew numerals.
+const hebrewThousands = "אלפים"
+
+// hebrewNumeral returns the gematria representation of a positive integer n.
+// Thousands are written as a letter followed by a geresh, e.g. 5783 is
+// written ה׳תשפ״ג. Multiples of 1000 are followed by the word אלפים, e.g.
+// ה׳ אלפים for 5000, since ה׳ alone is 5.
+func hebrewNumeral(n float64) string {
+	thousands := math.Floor(n / 1000)
+	prefix := ""
+	if thousands > 0 {
+		prefix = hebrewLettersFor(thousands) + string(geresh)
+		n = mod(n, 1000)
+		if n == 0 {
+			return prefix + " " + hebrewThousands
+		}
+	}
+	letters := hebrewLettersFor(n)
+	if utf8.RuneCountInString(letters) == 1 {
+		return prefix + letters + string(geresh)
+	}
+	_, size := utf8.DecodeLastRuneInString(letters)
+	return prefix + letters[:len(letters)-size] + string(gershayim) + letters[len(letters)-size:]
+}
+
+// hebrewLettersFor returns the Hebrew letters (without punctuation) summing up
+// to a given positive integer n. Hundreds beyond 400 are written by repeating
+// Tav, and 15 and 16 are written ט״ו and ט״ז to avoid spelling the divine
+// name.
+func hebrewLettersFor(n float64) string {
+	var b strings.Builder
+	n = math.Floor(n)
+	for n >= 400 {
+		b.WriteString("ת")
+		n -= 400
+	}
+	suffix := ""
+	switch mod(n, 100) {
+	case 15:
+		suffix, n = "טו", n-15
+	case 16:
+		suffix, n = "טז", n-16
+	}
+	for _, l := range hebrewLetters {
+		for n >= l.value {
+			b.WriteString(l.letter)
+			n -= l.value
+		}
+	}
+	b.WriteString(suffix)
+	return b.String()
+}
+
+// romanNumeral returns the Roman numeral of a positive integer n < 4000.
+func romanNumeral(n float64) string {
+	var b strings.Builder
+	for _, r := range romanNumerals {
+		for n >= r.value {
+			b.WriteString(r.numeral)
+			n -= r.value
+		}
+	}
+	return b.String()
+}
+
+// ErrInvalidNumeral is returned by ParseNumeral for strings that are not
+// numerals of any supported numeral system.
+var ErrInvalidNumeral = errors.New("libcalendar: invalid numeral")
+
+// ParseNumeral parses a number written in any of the supported numeral
+// systems, and returns its value.
+func ParseNumeral(s string) (float64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, ErrInvalidNumeral
+	}
+	first, _ := utf8.DecodeRuneInString(s)
+	switch {
+	case isHebrewLetter(first):
+		return parseHebrewNumeral(s)
+	case strings.ContainsRune("IVXLCDMivxlcdm", first):
+		return parseRomanNumeral(s)
+	default:
+		return parseDigits(s)
+	}
+}
+
+// parseDigits parses a (possibly signed or fractional) decimal number written
+// in Western, Eastern Arabic, Persian or Devanagari digits.
+func parseDigits(s string) (float64, error) {
+	western := strings.Map(func(r rune) rune {
+		switch {
+		case r >= '٠' && r <= '٩':
+			return '0' + (r - '٠')
+		case r >= '۰' && r <= '۹':
+			return '0' + (r - '۰')
+		case r >= '०' && r <= '९':
+			return '0' + (r - '०')
+		case r == '٫': // Arabic decimal separator
+			return '.'
+		default:
+			return r
+		}
+	}, s)
+	n, err := strconv.ParseFloat(western, 64)
+	if err != nil {
+		return 0, ErrInvalidNumeral
+	}
+	return n, nil
+}
+
+// isHebrewLetter returns true if r is a letter of the Hebrew alphabet.
+func isHebrewLetter(r rune) bool {
+	return r >= 'א' && r <= 'ת'
+}
+
+// hebrewFinalForms maps final letter forms to their regular forms.
+var hebrewFinalForms = strings.NewReplacer("ך", "כ", "ם", "מ", "ן", "נ", "ף", "פ", "ץ", "צ")
+
+// parseHebrewNumeral parses a number written in Hebrew numerals. A geresh
+// followed by further letters, or by the word אלפים, marks the preceding
+// letters as thousands.
+func parseHebrewNumeral(s string) (float64, error) {
+	if thousands, ok := strings.CutSuffix(s, hebrewThousands); ok && strings.TrimSpace(thousands) != "" {
+		n, err := parseHebrewNumeral(strings.TrimSpace(thousands))
+		if err != nil {
+			return 0, err
+		}
+		return 1000 * n, nil
+	}
+	s = hebrewFinalForms.Replace(s)
+	s = strings.NewReplacer(`"`, string(gershayim), "'", string(geresh)).Replace(s)
+	sum := func(letters string) (float64, error) {
+		total := 0.0
+		for _, r := range letters {
+			if r == gershayim || r == geresh {
+				continue
+			}
+			found := false
+			for _, l := range hebrewLetters {
+				if string(r) == l.letter {
+					total += l.value
+					found = true
+					break
+				}
+			}
+			if !found {
+				return 0, ErrInvalidNumeral
+			}
+		}
+		return total, nil
+	}
+	if i := strings.IndexRune(s, geresh); i >= 0 && i+utf8.RuneLen(geresh) < len(s) {
+		thousands, err := sum(s[:i])
+		if err != nil {
+			return 0, err
+		}
+		rest, err := sum(s[i+utf8.RuneLen(geresh):])
+		if err != nil {
+			return 0, err
+		}
+		return 1000*thousands + rest, nil
+	}
+	return sum(s)
+}
+
+// parseRomanNumeral parses a Roman numeral. Only numerals written as by
+// FormatNumeral are accepted, e.g. IV but neither IIII nor VX.
+func parseRomanNumeral(s string) (float64, error) {
+	s = strings.ToUpper(s)
+	numeral := s
+	n := 0.0
+	for len(s) > 0 {
+		found := false
+		for _, r := range romanNumerals {
+			if strings.HasPrefix(s, r.numeral) {
+				n += r.value
+				s = s[len(r.numeral):]
+				found = true
+				break
+			}
+		}
+		if !found {
+			return 0, ErrInvalidNumeral
+		}
+	}
+	if n >= 4000 || romanNumeral(n) != numeral {
+		return 0, ErrInvalidNumeral
+	}
+	return n, nil
+}
@@ -0,0 +1,113 @@
+// Copyright (C) 2022  Alexander Staudt
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package libcalendar
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestFormatNumeral(t *testing.T) {
+	tests := []struct {
+		n      float64
+		system NumeralSystem
+		want   string
+	}{
+		{1444, WesternNumerals, "1444"},
+		{1444, EasternArabicNumerals, "١٤٤٤"},
+		{5123, DevanagariNumerals, "५१२३"},
+		{1, HebrewNumerals, "א׳"},
+		{15, HebrewNumerals, "ט״ו"},
+		{16, HebrewNumerals, "ט״ז"},
+		{30, HebrewNumerals, "ל׳"},
+		{5783, HebrewNumerals, "ה׳תשפ״ג"},
+		{5715, HebrewNumerals, "ה׳תשט״ו"},
+		{5800, HebrewNumerals, "ה׳ת״ת"},
+		{1000, HebrewNumerals, "א׳ אלפים"},
+		{5000, HebrewNumerals, "ה׳ אלפים"},
+		{8, RomanNumerals, "VIII"},
+		{1994, RomanNumerals, "MCMXCIV"},
+		{0, RomanNumerals, "0"},
+	}
+
+	for _, tt := range tests {
+		testname := fmt.Sprintf("%.0f %v", tt.n, tt.system)
+		t.Run(testname, func(t *testing.T) {
+			got := FormatNumeral(tt.n, tt.system)
+			t.Logf("got %v, want %v", got, tt.want)
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			back, err := ParseNumeral(got)
+			if err != nil || back != tt.n {
+				t.Errorf("parsing %v: got %v (%v), want %v", got, back, err, tt.n)
+			}
+		})
+	}
+}
+
+func TestParseNumeralInvalid(t *testing.T) {
+	for _, s := range []string{"", "VX?", "x1", "אb", "VX", "IIII", "IC", "VV", "XM", "MMMM", "ivi"} {
+		if _, err := ParseNumeral(s); err == nil {
+			t.Errorf("ParseNumeral(%q): got no error", s)
+		}
+	}
+}
+
+func TestFormatAndParseDate(t *testing.T) {
+	tests := []struct {
+		date Date
+		opts FormatOptions
+		want string
+	}{
+		{HebrewDate{5783, nisan, 15}.Date(),
+			FormatOptions{Locale: "he", Numerals: HebrewNumerals}, "ט״ו בניסן ה׳תשפ״ג"},
+		{HebrewDate{5784, adar_ii, 14}.Date(),
+			FormatOptions{}, "14 Adar II 5784"},
+		{IslamicDate{1444, ramadan, 1}.Date(),
+			FormatOptions{Locale: "ar", Numerals: EasternArabicNumerals}, "١ رمضان ١٤٤٤"},
+		{FrenchDate{8, brumaire, 18}.Date(),
+			FormatOptions{YearNumerals: RomanNumerals}, "18 Brumaire an VIII"},
+		{OldHinduLunarDate{5123, chaitra, false, 9}.Date(),
+			FormatOptions{Locale: "hi", Numerals: DevanagariNumerals}, "९ चैत्र ५१२३"},
+		{OldHinduSolarDate{5123, mesha, 1}.Date(),
+			FormatOptions{Numerals: DevanagariNumerals}, "१ Mesha ५१२३"},
+		{HebrewDate{5000, tishri, 1}.Date(),
+			FormatOptions{Locale: "he", Numerals: HebrewNumerals}, "א׳ בתשרי ה׳ אלפים"},
+		{HinduLunarDate{2080, 5, true, 3, false}.Date(),
+			FormatOptions{}, "3 Adhika Sravana 2080"},
+		{HinduLunarDate{2078, 5, false, 27, true}.Date(),
+			FormatOptions{}, "27 (adhika) Sravana 2078"},
+	}
+
+	for _, tt := range tests {
+		testname := fmt.Sprintf("%v", tt.date)
+		t.Run(testname, func(t *testing.T) {
+			got := tt.date.Format(tt.opts)
+			t.Logf("got %v, want %v", got, tt.want)
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			parsed, err := ParseDate(got, tt.date.Calendar, tt.opts)
+			if err != nil {
+				t.Fatalf("parsing %v: %v", got, err)
+			}
+			if fmt.Sprint(parsed.Components) != fmt.Sprint(tt.date.Components) {
+				t.Errorf("parsing %v: got %v, want %v", got, parsed.Components, tt.date.Components)
+			}
+		})
+	}
+}
@@ -0,0 +1,185 @@
+// Copyright (C) 2022  Alexander Staudt
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// This file implements parsing of dates formatted by Format.
+
+package libcalendar
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ParseDate parses a date of a given calendar that is formatted according to
+// the locale and layout given in opts, and returns it as a Date. Days and
+// years may be written in any supported numeral system, regardless of
+// opts.Numerals. Month names are matched case-insensitively. Roman dates are
+// parsed by ParseRomanDate, regardless of opts. Leap months and days of the
+// Hindu lunar calendar are recognized by their adhika markers.
+func ParseDate(s string, calendar string, opts FormatOptions) (Date, error) {
+	if calendar == "roman" {
+		d, err := ParseRomanDate(s)
+		if err != nil {
+			return Date{}, err
+		}
+		return d.Date(), nil
+	}
+	if _, _, _, ok := (Date{Calendar: calendar, Components: []float64{0, 0, 0}}).dayMonthYear(); !ok {
+		return Date{}, fmt.Errorf("libcalendar: cannot parse dates of calendar %q", calendar)
+	}
+	// leap (adhika) months and days of the Hindu lunar calendar, marked as by
+	// Format
+	leapMonth, leapDay := false, false
+	if calendar == "hinduLunar" {
+		leapDay = adhikaDayPattern.MatchString(s)
+		s = adhikaDayPattern.ReplaceAllString(s, "")
+		leapMonth = adhikaMonthPattern.MatchString(s)
+		s = adhikaMonthPattern.ReplaceAllString(s, "")
+	}
+	tag := opts.Locale
+	if tag == "" {
+		tag = RootLocale
+	}
+	months := monthsByName(calendar, tag)
+	names := make([]string, 0, len(months))
+	for name := range months {
+		names = append(names, regexp.QuoteMeta(name))
+	}
+	// try longer names first, so that e.g. "Adar II" is not matched as "Adar"
+	sort.Slice(names, func(i, j int) bool { return len(names[i]) > len(names[j]) })
+
+	layout := strings.ReplaceAll(regexp.QuoteMeta(opts.layout(calendar)), " ", `\s+`)
+	order := []string{}
+	pattern := regexp.MustCompile(`\\\{(day|month|year)\\\}`).ReplaceAllStringFunc(layout, func(m string) string {
+		field := strings.Trim(m, `\{}`)
+		order = append(order, field)
+		if field == "month" {
+			return "(" + strings.Join(names, "|") + ")"
+		}
+		// multiples of 1000 in Hebrew numerals are followed by a word
+		return `(\S+?(?:\s+` + hebrewThousands + `)?)`
+	})
+	re, err := regexp.Compile(`(?i)^\s*` + pattern + `\s*$`)
+	if err != nil {
+		return Date{}, err
+	}
+	match := re.FindStringSubmatch(s)
+	if match == nil {
+		return Date{}, fmt.Errorf("libcalendar: cannot parse %q as %s date", s, calendar)
+	}
+
+	var day, month, year float64
+	for i, field := range order {
+		switch field {
+		case "day":
+			if day, err = ParseNumeral(match[i+1]); err != nil {
+				return Date{}, err
+			}
+		case "year":
+			if year, err = ParseNumeral(match[i+1]); err != nil {
+				return Date{}, err
+			}
+		case "month":
+			month = months[strings.ToLower(match[i+1])]
+		}
+	}
+
+	switch calendar {
+	case "mayanHaab", "mayanTzolkin", "aztecXihuitl", "aztecTonalpohualli":
+		return DateFromComponents(calendar, []float64{day, month}), nil
+	case "hinduLunar":
+		return DateFromComponents(calendar, []float64{year, month, day, flag(leapMonth), flag(leapDay)}), nil
+	default:
+		return DateFromComponents(calendar, []float64{year, month, day}), nil
+	}
+}
+
+// adhikaDayPattern and adhikaMonthPattern match the markers of leap days and
+// leap months of the Hindu lunar calendar.
+var (
+	adhikaDayPattern   = regexp.MustCompile(`(?i)\s*\(adhika\)`)
+	adhikaMonthPattern = regexp.MustCompile(`(?i)\badhika\s+`)
+)
+
+// monthsByName returns the month numbers of a calendar keyed by their lower
+// case names in a given locale, including leap month names.
+func monthsByName(calendar string, tag string) map[string]float64 {
+	months := map[string]float64{}
+	root, _ := LookupLocale(RootLocale)
+	calendar = monthCalendar(calendar)
+	for _, m := range keys(root.Months[calendar]) {
+		name, _ := lookupName(tag, m, func(l Locale) map[float64]string { return l.Months[calendar] })
+		months[strings.ToLower(strings.TrimSpace(name))] = m
+	}
+	for _, m := range keys(root.LeapMonths[calendar]) {
+		name, _ := lookupName(tag, m, func(l Locale) map[float64]string { return l.LeapMonths[calendar] })
+		months[strings.ToLower(strings.TrimSpace(name))] = m
+	}
+	delete(months, "")
+	return months
+}
+
+// romanDatePattern matches Roman dates as formatted by RomanDate.String. "ante
+// diem" may be abbreviated "a.d.", and "a.u.c." may be omitted.
+var romanDatePattern = regexp.MustCompile(`(?i)^\s*(?:(?:ante\s+diem|a\.\s*d\.)\s+(bis\s+)?(\S+)\s+|(pridie)\s+)?(\S+)\s+(\S+)\s+(\S+)(?:\s+a\.\s*u\.\s*c\.)?\s*$`)
+
+// ParseRomanDate parses a Roman date in Latin, e.g. "ante diem III Nonas
+// Martias 2775 a.u.c.", as formatted by RomanDate.String. Counts and years
+// may be written in any supported numeral system; names are matched
+// case-insensitively.
+func ParseRomanDate(s string) (RomanDate, error) {
+	match := romanDatePattern.FindStringSubmatch(s)
+	if match == nil {
+		return RomanDate{}, fmt.Errorf("libcalendar: cannot parse %q as roman date", s)
+	}
+	d := RomanDate{Count: 1, Leap: match[1] != ""}
+	events, months := romanEventsAblative, romanMonthsAblative
+	switch {
+	case match[2] != "":
+		count, err := ParseNumeral(match[2])
+		if err != nil {
+			return RomanDate{}, err
+		}
+		d.Count = count
+		events, months = romanEventsAccusative, romanMonthsAccusative
+	case match[3] != "":
+		d.Count = 2
+		events, months = romanEventsAccusative, romanMonthsAccusative
+	}
+	for event, name := range events {
+		if strings.EqualFold(name, match[4]) {
+			d.Event = event
+		}
+	}
+	for month, name := range months {
+		if strings.EqualFold(name, match[5]) {
+			d.Month = month
+		}
+	}
+	year, err := ParseNumeral(match[6])
+	if err != nil {
+		return RomanDate{}, err
+	}
+	d.Year = JulianYearFromAUC(year)
+	if d.Event == 0 || d.Month == 0 {
+		return RomanDate{}, fmt.Errorf("libcalendar: cannot parse %q as roman date", s)
+	}
+	if RomanFromAbsolute(AbsoluteFromRoman(d)) != d {
+		return RomanDate{}, fmt.Errorf("%w: %q", ErrInvalidDate, s)
+	}
+	return d, nil
+}
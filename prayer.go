@@ -0,0 +1,161 @@
+// Copyright (C) 2022  Alexander Staudt
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// This file implements the calculation of the daily Islamic prayer times and
+// of the direction of prayer (qibla).
+
+package libcalendar
+
+import "math"
+
+// PrayerMethod is a convention for the times of Fajr and Isha, given by the
+// depression of the sun at dawn and dusk.
+type PrayerMethod struct {
+	Name        string
+	FajrAngle   float64 // depression of the sun at Fajr, in degrees
+	IshaAngle   float64 // depression of the sun at Isha, in degrees, if IshaMinutes is zero
+	IshaMinutes float64 // minutes between Maghrib and Isha, used if not zero
+}
+
+// Conventions of the major authorities
+var (
+	MuslimWorldLeague = PrayerMethod{"Muslim World League", 18, 17, 0}
+	ISNA              = PrayerMethod{"Islamic Society of North America", 15, 15, 0}
+	Egyptian          = PrayerMethod{"Egyptian General Authority of Survey", 19.5, 17.5, 0}
+	UmmAlQura         = PrayerMethod{"Umm al-Qura University, Makkah", 18.5, 0, 90}
+	Karachi           = PrayerMethod{"University of Islamic Sciences, Karachi", 18, 18, 0}
+)
+
+// AsrMethod specifies the length of shadows at Asr.
+type AsrMethod int
+
+const (
+	// Shafii begins Asr when shadows exceed the length of their objects (the
+	// Shafi'i, Maliki and Hanbali schools).
+	Shafii AsrMethod = iota
+	// Hanafi begins Asr when shadows exceed twice the length of their objects.
+	Hanafi
+)
+
+// HighLatitudeRule specifies how Fajr and Isha are determined where the sun
+// does not reach their depression, or only late into the night.
+type HighLatitudeRule int
+
+const (
+	// HighLatitudeNone leaves such times undefined (NaN).
+	HighLatitudeNone HighLatitudeRule = iota
+	// MiddleOfTheNight limits Fajr and Isha to half the night from sunrise and
+	// sunset, respectively.
+	MiddleOfTheNight
+	// SeventhOfTheNight limits them to a seventh of the night.
+	SeventhOfTheNight
+	// AngleBased limits them to the fraction angle/60 of the night.
+	AngleBased
+)
+
+// PrayerOptions control the calculation of prayer times.
+type PrayerOptions struct {
+	Method       PrayerMethod // defaults to MuslimWorldLeague
+	Asr          AsrMethod
+	HighLatitude HighLatitudeRule
+}
+
+// PrayerTimes are the times of the daily prayers, and of sunrise, in standard
+// time. Times that do not occur are NaN.
+type PrayerTimes struct {
+	Fajr    Moment
+	Sunrise Moment
+	Dhuhr   Moment
+	Asr     Moment
+	Maghrib Moment
+	Isha    Moment
+}
+
+// noMoment is the Moment returned for times that do not occur.
+var noMoment = Moment(math.NaN())
+
+// orNaN returns m if ok, and NaN otherwise.
+func orNaN(m Moment, ok bool) Moment {
+	if !ok {
+		return noMoment
+	}
+	return m
+}
+
+// asrTime returns the moment, in standard time, at which shadows exceed
+// their noon length by `factor` times the length of their objects.
+func asrTime(absoluteDate float64, l Location, factor float64) (Moment, bool) {
+	_, declination, _ := solarPosition(UniversalFromStandard(SolarNoon(absoluteDate, l), l))
+	altitude := degrees(math.Atan(1 / (factor + math.Tan(radians(math.Abs(l.Latitude-declination))))))
+	return Dusk(absoluteDate, l, -altitude)
+}
+
+// DailyPrayerTimes returns the prayer times on a given absolute date at a
+// location.
+func DailyPrayerTimes(absoluteDate float64, l Location, opts PrayerOptions) PrayerTimes {
+	method := opts.Method
+	if method == (PrayerMethod{}) {
+		method = MuslimWorldLeague
+	}
+	p := PrayerTimes{
+		Fajr:    orNaN(Dawn(absoluteDate, l, method.FajrAngle)),
+		Sunrise: orNaN(Sunrise(absoluteDate, l)),
+		Dhuhr:   SolarNoon(absoluteDate, l),
+		Asr:     orNaN(asrTime(absoluteDate, l, float64(opts.Asr)+1)),
+		Maghrib: orNaN(Sunset(absoluteDate, l)),
+		Isha:    orNaN(Dusk(absoluteDate, l, method.IshaAngle)),
+	}
+	if method.IshaMinutes != 0 {
+		p.Isha = p.Maghrib + Moment(method.IshaMinutes/1440)
+	}
+
+	nextSunrise, ok := Sunrise(absoluteDate+1, l)
+	if opts.HighLatitude == HighLatitudeNone || !ok || math.IsNaN(float64(p.Maghrib)) {
+		return p
+	}
+	night := float64(nextSunrise - p.Maghrib)
+	portion := func(angle float64) Moment {
+		switch opts.HighLatitude {
+		case MiddleOfTheNight:
+			return Moment(night / 2)
+		case SeventhOfTheNight:
+			return Moment(night / 7)
+		default:
+			return Moment(night * angle / 60)
+		}
+	}
+	if limit := p.Sunrise - portion(method.FajrAngle); math.IsNaN(float64(p.Fajr)) || p.Fajr < limit {
+		p.Fajr = limit
+	}
+	if method.IshaMinutes == 0 {
+		if limit := p.Maghrib + portion(method.IshaAngle); math.IsNaN(float64(p.Isha)) || p.Isha > limit {
+			p.Isha = limit
+		}
+	}
+	return p
+}
+
+// Location of the Kaaba in Mecca
+var kaaba = Location{Latitude: 21.4225, Longitude: 39.8262}
+
+// Qibla returns the direction of the Kaaba in Mecca from a location, as the
+// initial bearing of the great circle in degrees clockwise from north.
+func Qibla(l Location) float64 {
+	phi, phiK := radians(l.Latitude), radians(kaaba.Latitude)
+	deltaLambda := radians(kaaba.Longitude - l.Longitude)
+	direction := math.Atan2(math.Sin(deltaLambda),
+		math.Cos(phi)*math.Tan(phiK)-math.Sin(phi)*math.Cos(deltaLambda))
+	return mod(degrees(direction), 360)
+}
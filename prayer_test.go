@@ -0,0 +1,86 @@
+// Copyright (C) 2022  Alexander Staudt
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package libcalendar
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestDailyPrayerTimes(t *testing.T) {
+	newYork, _ := time.LoadLocation("America/New_York")
+	london, _ := time.LoadLocation("Europe/London")
+	var tests = []struct {
+		name     string
+		location Location
+		opts     PrayerOptions
+		want     string // Fajr, Sunrise, Dhuhr, Asr, Maghrib, Isha
+	}{
+		{"New York, ISNA", Location{40.7128, -74.0060, 0, 0, newYork}, PrayerOptions{Method: ISNA},
+			"03:44 05:24 12:56 16:56 20:29 22:08"},
+		{"Mecca, Umm al-Qura", Location{21.4225, 39.8262, 0, 3, nil}, PrayerOptions{Method: UmmAlQura},
+			"04:10 05:38 12:21 15:40 19:04 20:34"},
+		{"Karachi, Hanafi", Location{24.86, 67.01, 0, 5, nil}, PrayerOptions{Method: Karachi, Asr: Hanafi},
+			"04:13 05:42 12:32 17:15 19:22 20:51"},
+		{"London, MWL", Location{51.5074, -0.1278, 0, 0, london}, PrayerOptions{},
+			"--:-- 04:42 13:01 17:23 21:19 --:--"},
+		{"London, angle based", Location{51.5074, -0.1278, 0, 0, london}, PrayerOptions{HighLatitude: AngleBased},
+			"02:29 04:42 13:01 17:23 21:19 23:25"},
+		{"London, seventh of the night", Location{51.5074, -0.1278, 0, 0, london}, PrayerOptions{HighLatitude: SeventhOfTheNight},
+			"03:39 04:42 13:01 17:23 21:19 22:22"},
+	}
+	clock := func(m Moment) string {
+		if math.IsNaN(float64(m)) {
+			return "--:--"
+		}
+		return m.Time().Format("15:04")
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := DailyPrayerTimes(AbsoluteFromGregorian(GregorianDate{2022, 6, 15}), tt.location, tt.opts)
+			ans := fmt.Sprint(clock(p.Fajr), " ", clock(p.Sunrise), " ", clock(p.Dhuhr), " ",
+				clock(p.Asr), " ", clock(p.Maghrib), " ", clock(p.Isha))
+			t.Logf("got %v, want %v", ans, tt.want)
+			if ans != tt.want {
+				t.Errorf("got %v, want %v", ans, tt.want)
+			}
+		})
+	}
+}
+
+func TestQibla(t *testing.T) {
+	var tests = []struct {
+		name     string
+		location Location
+		want     float64
+	}{
+		{"New York", Location{Latitude: 40.7128, Longitude: -74.0060}, 58.48},
+		{"London", Location{Latitude: 51.5074, Longitude: -0.1278}, 118.99},
+		{"Jakarta", Location{Latitude: -6.2088, Longitude: 106.8456}, 295.15},
+		{"Medina", Location{Latitude: 24.4686, Longitude: 39.6142}, 176.29},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ans := Qibla(tt.location)
+			t.Logf("got %v, want %v", ans, tt.want)
+			if math.Abs(ans-tt.want) > 0.01 {
+				t.Errorf("got %v, want %v", ans, tt.want)
+			}
+		})
+	}
+}
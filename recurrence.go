@@ -0,0 +1,510 @@
+// Copyright (C) 2022  Alexander Staudt
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// This file implements yearly and monthly recurrence rules in any calendar
+// with months, following the RSCALE and SKIP semantics of RFC 7529
+// ("Non-Gregorian Recurrence Rules in the Internet Calendaring and
+// Scheduling Core Object Specification").
+
+package libcalendar
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidRecurrenceRule is returned for recurrence rules that are
+// malformed or use unsupported calendars, months or days.
+var ErrInvalidRecurrenceRule = errors.New("libcalendar: invalid recurrence rule")
+
+// Frequency specifies how often a recurrence rule repeats.
+type Frequency int
+
+// Frequencies
+const (
+	Yearly Frequency = iota
+	Monthly
+)
+
+// SkipPolicy specifies how a recurrence rule treats days and leap months
+// that do not exist in a given year (RFC 7529 SKIP).
+type SkipPolicy int
+
+const (
+	// SkipOmit omits occurrences on non-existent dates.
+	SkipOmit SkipPolicy = iota
+	// SkipBackward moves an occurrence to the previous valid date: the last
+	// day of a short month, the month preceding a missing leap month, or the
+	// day preceding an expunged day.
+	SkipBackward
+	// SkipForward moves an occurrence to the next valid date: the first day
+	// after a short month, the month following a missing leap month, or the
+	// day following an expunged day.
+	SkipForward
+)
+
+// RecurrenceRule is a yearly or monthly recurrence in a given calendar, e.g.
+// "every 15 Shevat" or "every 1st of Ramadan".
+//
+// Months are numbered as in the calendar's date type. For the Hebrew
+// calendar, month 12 denotes Adar in common years and Adar II in leap years
+// (as does month 13), while month 12 with LeapMonth denotes Adar I, which
+// only exists in leap years. For the Old Hindu lunar calendar, LeapMonth
+// denotes the leap month preceding the month of the same name.
+type RecurrenceRule struct {
+	Calendar  string     // calendar with months, e.g. "hebrew"
+	Frequency Frequency  // Yearly or Monthly
+	Interval  float64    // years or months between occurrences, defaults to 1
+	Month     float64    // month; defaults to the month of Start for yearly rules, every month for monthly rules
+	LeapMonth bool       // Month denotes a leap month
+	Day       float64    // day of month, negative days count from the end; defaults to the day of Start
+	Skip      SkipPolicy // treatment of non-existent dates
+	Start     float64    // absolute date of the first possible occurrence (DTSTART)
+	Count     int        // maximum number of occurrences from Start, or 0 for no limit
+}
+
+// recurrenceMonth is a month of a year of a given calendar.
+type recurrenceMonth struct {
+	year, month float64
+	leap        bool
+}
+
+// monthsInYear returns the largest month number of a calendar.
+func monthsInYear(calendar string) float64 {
+	switch calendar {
+	case "gregorian", "julian", "islamic", "oldHinduSolar", "oldHinduLunar":
+		return 12
+	case "hebrew", "french":
+		return 13
+	default:
+		return 0
+	}
+}
+
+// monthOfDate returns the month containing a given absolute date.
+func monthOfDate(calendar string, absoluteDate float64) recurrenceMonth {
+	if calendar == "oldHinduLunar" {
+		d := OldHinduLunarFromAbsolute(absoluteDate)
+		return recurrenceMonth{d.Year, d.Month, d.LeapMonth}
+	}
+	_, month, year, _ := DateFromAbsolute(absoluteDate, calendar).dayMonthYear()
+	return recurrenceMonth{year, month, false}
+}
+
+// nextMonth returns the month following a given month.
+func nextMonth(calendar string, m recurrenceMonth) recurrenceMonth {
+	switch calendar {
+	case "hebrew":
+		month, year := hebrewMonthFromIndex(hebrewMonthIndex(m.month, m.year) + 1)
+		return recurrenceMonth{year, month, false}
+	case "oldHinduLunar":
+		first, _ := monthDay(calendar, m, 1, SkipForward)
+		next := oldHinduLunarMonthOf(add(oldHinduLunarMonthStart(first), LunarSynodicMonth))
+		return recurrenceMonth{next.Year, next.Month, next.LeapMonth}
+	case "french":
+		if m.month == 13 {
+			return recurrenceMonth{m.year + 1, 1, false}
+		}
+	default:
+		if m.month == 12 {
+			return recurrenceMonth{m.year + 1, 1, false}
+		}
+	}
+	return recurrenceMonth{m.year, m.month + 1, false}
+}
+
+// lastDayOfMonth returns the number of days of a given month, for calendars
+// without expunged days.
+func lastDayOfMonth(calendar string, year, month float64) float64 {
+	switch calendar {
+	case "gregorian":
+		return LastDayOfGregorianMonth(month, year)
+	case "julian":
+		return LastDayOfJulianMonth(month, year)
+	case "islamic":
+		return LastDayOfIslamicMonth(month, year)
+	case "hebrew":
+		return LastDayOfHebrewMonth(month, year)
+	case "french":
+		return FrenchLastDayOfMonth(month, year)
+	case "oldHinduSolar":
+		return LastDayOfOldHinduSolarMonth(month, year)
+	default:
+		return 0
+	}
+}
+
+// monthDay returns the absolute date of a given day of a month. Negative days
+// count from the end of the month. Non-existent days are resolved according
+// to skip; ok is false if the day is omitted.
+func monthDay(calendar string, m recurrenceMonth, day float64, skip SkipPolicy) (absoluteDate float64, ok bool) {
+	if calendar == "oldHinduLunar" {
+		return oldHinduLunarMonthDay(m, day, skip)
+	}
+	first := AbsoluteFromDate(DateFromComponents(calendar, []float64{m.year, m.month, 1}))
+	last := lastDayOfMonth(calendar, m.year, m.month)
+	if day < 0 {
+		day += last + 1
+	}
+	switch {
+	case day < 1:
+		return 0, false
+	case day <= last:
+		return first + day - 1, true
+	case skip == SkipBackward:
+		return first + last - 1, true
+	case skip == SkipForward:
+		return first + last, true
+	default:
+		return 0, false
+	}
+}
+
+// oldHinduLunarMonthDay implements monthDay for the Old Hindu lunar calendar,
+// whose months may lack days in their middle (expunged days).
+func oldHinduLunarMonthDay(m recurrenceMonth, day float64, skip SkipPolicy) (float64, bool) {
+	date := func(day float64) float64 {
+		return AbsoluteFromOldHinduLunar(OldHinduLunarDate{m.year, m.month, m.leap, day})
+	}
+	last := 30.0
+	for last > 0 && math.IsNaN(date(last)) {
+		last--
+	}
+	if last == 0 {
+		return 0, false
+	}
+	if day < 0 {
+		day += last + 1
+	}
+	if day < 1 {
+		return 0, false
+	}
+	if d := date(day); !math.IsNaN(d) {
+		return d, true
+	}
+	switch skip {
+	case SkipBackward:
+		for ; day >= 1; day-- {
+			if d := date(day); !math.IsNaN(d) {
+				return d, true
+			}
+		}
+		first, _ := oldHinduLunarMonthDay(m, 1, SkipForward)
+		return first - 1, true
+	case SkipForward:
+		for ; day <= last; day++ {
+			if d := date(day); !math.IsNaN(d) {
+				return d, true
+			}
+		}
+		return date(last) + 1, true
+	default:
+		return 0, false
+	}
+}
+
+// monthOfYear returns the month of a given year denoted by a rule's Month and
+// LeapMonth, resolving missing leap months according to skip.
+func (r RecurrenceRule) monthOfYear(year float64) (recurrenceMonth, bool) {
+	m := recurrenceMonth{year, r.Month, r.LeapMonth}
+	switch r.Calendar {
+	case "hebrew":
+		switch {
+		case r.Month >= 12 && !r.LeapMonth:
+			m.month = LastMonthOfHebrewYear(year)
+		case r.LeapMonth && !HebrewLeapYear(year):
+			return skipLeapMonth(r.Calendar, recurrenceMonth{year, adar, false}, r.Skip)
+		}
+		m.leap = false
+	case "oldHinduLunar":
+		if r.LeapMonth && !oldHinduLunarHasLeapMonth(year, r.Month) {
+			return skipLeapMonth(r.Calendar, recurrenceMonth{year, r.Month, false}, r.Skip)
+		}
+	}
+	return m, true
+}
+
+// skipLeapMonth resolves a missing leap month, given the ordinary month that
+// follows it.
+func skipLeapMonth(calendar string, ordinary recurrenceMonth, skip SkipPolicy) (recurrenceMonth, bool) {
+	switch skip {
+	case SkipForward:
+		return ordinary, true
+	case SkipBackward:
+		if calendar == "hebrew" {
+			return recurrenceMonth{ordinary.year, shevat, false}, true
+		}
+		if ordinary.month == 1 {
+			return recurrenceMonth{ordinary.year - 1, 12, false}, true
+		}
+		return recurrenceMonth{ordinary.year, ordinary.month - 1, false}, true
+	default:
+		return recurrenceMonth{}, false
+	}
+}
+
+// validate checks a rule and fills in defaults from its Start.
+func (r RecurrenceRule) validate() (RecurrenceRule, error) {
+	maxMonth := monthsInYear(r.Calendar)
+	if maxMonth == 0 {
+		return r, fmt.Errorf("%w: no months in calendar %q", ErrInvalidRecurrenceRule, r.Calendar)
+	}
+	if r.Interval == 0 {
+		r.Interval = 1
+	}
+	start := monthOfDate(r.Calendar, r.Start)
+	if r.Frequency == Yearly && r.Month == 0 {
+		r.Month, r.LeapMonth = start.month, start.leap
+		if r.Calendar == "hebrew" && r.Month == 12 && HebrewLeapYear(start.year) {
+			r.LeapMonth = true
+		}
+	}
+	if r.Day == 0 {
+		day, _, _, _ := DateFromAbsolute(r.Start, r.Calendar).dayMonthYear()
+		r.Day = day
+	}
+	switch {
+	case r.Frequency != Yearly && r.Frequency != Monthly,
+		r.Interval < 1 || r.Interval != math.Floor(r.Interval),
+		r.Month < 0 || r.Month > maxMonth || r.Month != math.Floor(r.Month),
+		r.Day < -31 || r.Day > 31 || r.Day != math.Floor(r.Day),
+		r.Count < 0,
+		r.LeapMonth && r.Calendar != "oldHinduLunar" && !(r.Calendar == "hebrew" && r.Month == 12),
+		math.IsNaN(r.Start):
+		return r, ErrInvalidRecurrenceRule
+	}
+	return r, nil
+}
+
+// Occurrences returns the absolute dates of the occurrences of a rule from
+// absolute date `from` through absolute date `to`, in chronological order.
+func (r RecurrenceRule) Occurrences(from, to float64) ([]float64, error) {
+	r, err := r.validate()
+	if err != nil {
+		return nil, err
+	}
+	dates := []float64{}
+	count := 0
+	// emit records an occurrence and returns false once no further
+	// occurrences are needed.
+	emit := func(date float64) bool {
+		if date < r.Start {
+			return true
+		}
+		count++
+		if r.Count > 0 && count > r.Count {
+			return false
+		}
+		if from <= date && date <= to {
+			dates = append(dates, date)
+		}
+		return true
+	}
+	start := monthOfDate(r.Calendar, r.Start)
+
+	if r.Frequency == Yearly {
+		for year := start.year; ; year += r.Interval {
+			m, ok := r.monthOfYear(year)
+			if ok {
+				date, ok := monthDay(r.Calendar, m, r.Day, r.Skip)
+				if ok && date > to {
+					break
+				}
+				if ok && !emit(date) {
+					break
+				}
+			}
+			// stop once the year has passed `to`
+			if first, _ := monthDay(r.Calendar, recurrenceMonth{year, 1, false}, 1, SkipForward); first > to {
+				break
+			}
+		}
+		return dates, nil
+	}
+
+	for m, i := start, 0.0; ; m, i = nextMonth(r.Calendar, m), i+1 {
+		first, _ := monthDay(r.Calendar, m, 1, SkipForward)
+		if first > to {
+			break
+		}
+		if math.Mod(i, r.Interval) != 0 {
+			continue
+		}
+		if r.Month != 0 {
+			if target, ok := r.monthOfYear(m.year); !ok || target != m {
+				continue
+			}
+		}
+		if date, ok := monthDay(r.Calendar, m, r.Day, r.Skip); ok && !emit(date) {
+			break
+		}
+	}
+	return dates, nil
+}
+
+// RFC 7529 RSCALE values of the supported calendars. Calendars without a
+// CLDR identifier use their libcalendar name.
+var rscales = map[string]string{
+	"gregorian":     "GREGORIAN",
+	"julian":        "JULIAN",
+	"hebrew":        "HEBREW",
+	"islamic":       "ISLAMIC-CIVIL",
+	"french":        "FRENCH",
+	"oldHinduSolar": "OLDHINDUSOLAR",
+	"oldHinduLunar": "OLDHINDULUNAR",
+}
+
+// cldrHebrewMonth converts a Hebrew month and leap flag of a rule into the
+// CLDR month number used by RFC 7529, in which the year begins with Tishri
+// (1) and Adar I is "5L".
+func cldrHebrewMonth(month float64, leap bool) float64 {
+	switch {
+	case leap:
+		return 5
+	case month >= 12:
+		return 6
+	case month >= tishri:
+		return month - 6
+	default:
+		return month + 6
+	}
+}
+
+// String returns the rule as an RFC 7529 RRULE value.
+func (r RecurrenceRule) String() string {
+	parts := []string{"RSCALE=" + rscales[r.Calendar]}
+	if r.Frequency == Monthly {
+		parts = append(parts, "FREQ=MONTHLY")
+	} else {
+		parts = append(parts, "FREQ=YEARLY")
+	}
+	if r.Interval > 1 {
+		parts = append(parts, fmt.Sprintf("INTERVAL=%v", r.Interval))
+	}
+	if r.Count > 0 {
+		parts = append(parts, fmt.Sprintf("COUNT=%v", r.Count))
+	}
+	if r.Month != 0 {
+		month := r.Month
+		if r.Calendar == "hebrew" {
+			month = cldrHebrewMonth(r.Month, r.LeapMonth)
+		}
+		leap := ""
+		if r.LeapMonth {
+			leap = "L"
+		}
+		parts = append(parts, fmt.Sprintf("BYMONTH=%v%s", month, leap))
+	}
+	if r.Day != 0 {
+		parts = append(parts, fmt.Sprintf("BYMONTHDAY=%v", r.Day))
+	}
+	switch r.Skip {
+	case SkipBackward:
+		parts = append(parts, "SKIP=BACKWARD")
+	case SkipForward:
+		parts = append(parts, "SKIP=FORWARD")
+	}
+	return strings.Join(parts, ";")
+}
+
+// ParseRecurrenceRule parses an RFC 7529 RRULE value with the parts RSCALE
+// (default GREGORIAN), FREQ (YEARLY or MONTHLY), INTERVAL, COUNT, BYMONTH
+// (a single month, "L" marking a leap month), BYMONTHDAY (a single day) and
+// SKIP. Hebrew months are numbered as in CLDR, e.g. "5L" for Adar I. The rule
+// starts on a given absolute date (DTSTART).
+func ParseRecurrenceRule(s string, start float64) (RecurrenceRule, error) {
+	r := RecurrenceRule{Calendar: "gregorian", Frequency: -1, Start: start}
+	invalid := func(part string) (RecurrenceRule, error) {
+		return RecurrenceRule{}, fmt.Errorf("%w: %q", ErrInvalidRecurrenceRule, part)
+	}
+	var cldrMonth float64
+	for _, part := range strings.Split(strings.TrimPrefix(s, "RRULE:"), ";") {
+		name, value, found := strings.Cut(part, "=")
+		if !found {
+			return invalid(part)
+		}
+		value = strings.ToUpper(value)
+		var err error
+		switch strings.ToUpper(name) {
+		case "RSCALE":
+			r.Calendar = ""
+			for calendar, rscale := range rscales {
+				if value == rscale || value == strings.ToUpper(calendar) {
+					r.Calendar = calendar
+				}
+			}
+			if r.Calendar == "" {
+				return invalid(part)
+			}
+		case "FREQ":
+			switch value {
+			case "YEARLY":
+				r.Frequency = Yearly
+			case "MONTHLY":
+				r.Frequency = Monthly
+			default:
+				return invalid(part)
+			}
+		case "INTERVAL":
+			r.Interval, err = strconv.ParseFloat(value, 64)
+		case "COUNT":
+			r.Count, err = strconv.Atoi(value)
+		case "BYMONTH":
+			r.LeapMonth = strings.HasSuffix(value, "L")
+			cldrMonth, err = strconv.ParseFloat(strings.TrimSuffix(value, "L"), 64)
+			r.Month = cldrMonth
+		case "BYMONTHDAY":
+			r.Day, err = strconv.ParseFloat(value, 64)
+		case "SKIP":
+			switch value {
+			case "OMIT":
+				r.Skip = SkipOmit
+			case "BACKWARD":
+				r.Skip = SkipBackward
+			case "FORWARD":
+				r.Skip = SkipForward
+			default:
+				return invalid(part)
+			}
+		default:
+			return invalid(part)
+		}
+		if err != nil {
+			return invalid(part)
+		}
+	}
+	if r.Frequency == -1 {
+		return invalid(s)
+	}
+	if r.Calendar == "hebrew" && cldrMonth != 0 {
+		switch {
+		case r.LeapMonth && cldrMonth != 5, cldrMonth < 1 || cldrMonth > 12:
+			return invalid(s)
+		case r.LeapMonth || cldrMonth == 6:
+			r.Month = adar
+		case cldrMonth <= 5:
+			r.Month = cldrMonth + 6
+		default:
+			r.Month = cldrMonth - 6
+		}
+	}
+	if _, err := r.validate(); err != nil {
+		return RecurrenceRule{}, err
+	}
+	return r, nil
+}
@@ -0,0 +1,113 @@
+// Copyright (C) 2022  Alexander Staudt
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package libcalendar
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestRecurrenceRuleOccurrences(t *testing.T) {
+	start := AbsoluteFromGregorian(GregorianDate{2020, 1, 1})
+	end := AbsoluteFromGregorian(GregorianDate{2025, 12, 31})
+	var tests = []struct {
+		name string
+		rule RecurrenceRule
+		to   float64
+		want []string // Gregorian dates
+	}{
+		{"Tu BiShvat", RecurrenceRule{Calendar: "hebrew", Month: shevat, Day: 15}, end,
+			[]string{"10 February 2020", "28 January 2021", "17 January 2022", "6 February 2023", "25 January 2024", "13 February 2025"}},
+		{"1 Ramadan", RecurrenceRule{Calendar: "islamic", Month: 9, Day: 1, Interval: 2}, end,
+			[]string{"24 April 2020", "3 April 2022", "11 March 2024"}},
+		{"9 Thermidor", RecurrenceRule{Calendar: "french", Month: 11, Day: 9, Count: 2}, end,
+			[]string{"26 July 2020", "27 July 2021"}},
+		{"Adar I, omit", RecurrenceRule{Calendar: "hebrew", Month: adar, LeapMonth: true, Day: 14}, end,
+			[]string{"15 February 2022", "23 February 2024"}},
+		{"Adar I, backward", RecurrenceRule{Calendar: "hebrew", Month: adar, LeapMonth: true, Day: 14, Skip: SkipBackward}, end,
+			[]string{"9 February 2020", "27 January 2021", "15 February 2022", "5 February 2023", "23 February 2024", "12 February 2025"}},
+		{"Adar I, forward", RecurrenceRule{Calendar: "hebrew", Month: adar, LeapMonth: true, Day: 14, Skip: SkipForward}, end,
+			[]string{"10 March 2020", "26 February 2021", "15 February 2022", "7 March 2023", "23 February 2024", "14 March 2025"}},
+		{"30 Heshvan, backward", RecurrenceRule{Calendar: "hebrew", Month: 8, Day: 30, Skip: SkipBackward}, end,
+			[]string{"16 November 2020", "4 November 2021", "24 November 2022", "13 November 2023", "1 December 2024", "20 November 2025"}},
+		{"monthly 31st, omit", RecurrenceRule{Calendar: "gregorian", Frequency: Monthly, Day: 31},
+			AbsoluteFromGregorian(GregorianDate{2020, 6, 30}),
+			[]string{"31 January 2020", "31 March 2020", "31 May 2020"}},
+		{"monthly 31st, forward", RecurrenceRule{Calendar: "gregorian", Frequency: Monthly, Day: 31, Skip: SkipForward},
+			AbsoluteFromGregorian(GregorianDate{2020, 4, 30}),
+			[]string{"31 January 2020", "1 March 2020", "31 March 2020"}},
+		{"last day of every other month", RecurrenceRule{Calendar: "hebrew", Frequency: Monthly, Interval: 2, Day: -1, Count: 4}, end,
+			[]string{"26 January 2020", "25 March 2020", "23 May 2020", "21 July 2020"}},
+		{"Old Hindu lunar new moons", RecurrenceRule{Calendar: "oldHinduLunar", Frequency: Monthly, Day: 1},
+			AbsoluteFromGregorian(GregorianDate{2020, 4, 30}),
+			[]string{"26 January 2020", "24 February 2020", "25 March 2020", "23 April 2020"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.rule.Start = start
+			dates, err := tt.rule.Occurrences(start, tt.to)
+			got := []string{}
+			for _, d := range dates {
+				got = append(got, FromAbsolute(d, "gregorian"))
+			}
+			t.Logf("got %v, want %v", got, tt.want)
+			if err != nil || fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("got %v (%v), want %v", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRecurrenceRule(t *testing.T) {
+	start := AbsoluteFromGregorian(GregorianDate{2020, 1, 1})
+	var tests = []struct {
+		input string
+		want  RecurrenceRule
+		err   error
+	}{
+		{"RRULE:RSCALE=HEBREW;FREQ=YEARLY;BYMONTH=5L;BYMONTHDAY=14;SKIP=FORWARD",
+			RecurrenceRule{Calendar: "hebrew", Month: adar, LeapMonth: true, Day: 14, Skip: SkipForward, Start: start}, nil},
+		{"RSCALE=HEBREW;FREQ=YEARLY;BYMONTH=5;BYMONTHDAY=15",
+			RecurrenceRule{Calendar: "hebrew", Month: shevat, Day: 15, Start: start}, nil},
+		{"RSCALE=ISLAMIC-CIVIL;FREQ=MONTHLY;INTERVAL=2;COUNT=10;BYMONTHDAY=-1;SKIP=BACKWARD",
+			RecurrenceRule{Calendar: "islamic", Frequency: Monthly, Interval: 2, Count: 10, Day: -1, Skip: SkipBackward, Start: start}, nil},
+		{"FREQ=YEARLY", RecurrenceRule{Calendar: "gregorian", Start: start}, nil},
+		{"RSCALE=CHINESE;FREQ=YEARLY", RecurrenceRule{}, ErrInvalidRecurrenceRule},
+		{"RSCALE=HEBREW;FREQ=YEARLY;BYMONTH=4L", RecurrenceRule{}, ErrInvalidRecurrenceRule},
+		{"RSCALE=GREGORIAN;FREQ=YEARLY;BYMONTH=2L", RecurrenceRule{}, ErrInvalidRecurrenceRule},
+		{"FREQ=WEEKLY", RecurrenceRule{}, ErrInvalidRecurrenceRule},
+		{"BYMONTHDAY=1", RecurrenceRule{}, ErrInvalidRecurrenceRule},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseRecurrenceRule(tt.input, start)
+			t.Logf("got %+v, want %+v", got, tt.want)
+			if got != tt.want || !errors.Is(err, tt.err) {
+				t.Errorf("got %+v (%v), want %+v (%v)", got, err, tt.want, tt.err)
+			}
+		})
+		if tt.err == nil {
+			t.Run(tt.input+" round trip", func(t *testing.T) {
+				got, err := ParseRecurrenceRule(tt.want.String(), start)
+				t.Logf("got %+v, want %+v", got, tt.want)
+				if got != tt.want || err != nil {
+					t.Errorf("got %+v (%v), want %+v", got, err, tt.want)
+				}
+			})
+		}
+	}
+}
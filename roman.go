@@ -0,0 +1,131 @@
+// Copyright (C) 2022  Alexander Staudt
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// This file implements the Roman nomenclature of the days of the Julian
+// calendar, which counts the days down to the next of three reference days
+// of the month: the Kalends (the 1st), the Nones (the 5th or 7th) and the
+// Ides (the 13th or 15th). Years are counted from the founding of Rome (ab
+// urbe condita, A.U.C.) (see Reingold/Dershowitz 2018).
+
+package libcalendar
+
+// Roman date type. Days are counted inclusively, so that the day before a
+// reference day (pridie) has Count 2.
+type RomanDate struct {
+	Year  float64 // Julian year of the reference day
+	Month float64 // month of the reference day
+	Event float64 // RomanKalends, RomanNones or RomanIdes
+	Count float64 // days up to and including the reference day, 1 on the reference day
+	Leap  bool    // the bissextile day of leap years, following ante diem VI Kalendas Martias
+}
+
+// Reference days of the Roman month
+const (
+	RomanKalends = 1
+	RomanNones   = 2
+	RomanIdes    = 3
+)
+
+// aucOffset is the number of years from the founding of Rome (1 A.U.C. =
+// 753 B.C.E.) to the Julian year 0.
+const aucOffset = 753
+
+// AUCFromJulianYear returns the year A.U.C. of a given Julian year. Julian
+// years are numbered astronomically, i.e. 1 B.C.E. is the year 0.
+func AUCFromJulianYear(year float64) float64 {
+	return year + aucOffset
+}
+
+// JulianYearFromAUC returns the Julian year of a given year A.U.C.
+func JulianYearFromAUC(year float64) float64 {
+	return year - aucOffset
+}
+
+// AUC returns the year A.U.C. of its receiver.
+func (d RomanDate) AUC() float64 {
+	return AUCFromJulianYear(d.Year)
+}
+
+// romanIdes returns the day of the Ides of a given month: the 15th of March,
+// May, July and October, and the 13th otherwise.
+func romanIdes(month float64) float64 {
+	switch month {
+	case march, may, july, october:
+		return 15
+	default:
+		return 13
+	}
+}
+
+// romanNones returns the day of the Nones of a given month, eight days
+// before the Ides.
+func romanNones(month float64) float64 {
+	return romanIdes(month) - 8
+}
+
+// AbsoluteFromRoman computes the absolute (fixed) date from a given Roman
+// date.
+func AbsoluteFromRoman(d RomanDate) (absoluteDate float64) {
+	day := 1.0
+	switch d.Event {
+	case RomanNones:
+		day = romanNones(d.Month)
+	case RomanIdes:
+		day = romanIdes(d.Month)
+	}
+	absoluteDate = AbsoluteFromJulian(JulianDate{d.Year, d.Month, day}) - d.Count + 1
+	// in leap years, the days from ante diem VI to ante diem XVI Kalendas
+	// Martias are one day earlier than their count suggests
+	if mod(d.Year, 4) == 0 && d.Month == march && d.Event == RomanKalends && d.Count >= 6 && d.Count <= 16 {
+		absoluteDate--
+	}
+	return absoluteDate + flag(d.Leap)
+}
+
+// RomanFromAbsolute computes the Roman date from a given absolute (fixed)
+// date.
+func RomanFromAbsolute(absoluteDate float64) RomanDate {
+	j := JulianFromAbsolute(absoluteDate)
+	nextMonth := amod(j.Month+1, 12)
+	nextYear := j.Year
+	if nextMonth == january {
+		nextYear++
+	}
+	kalends := AbsoluteFromRoman(RomanDate{nextYear, nextMonth, RomanKalends, 1, false})
+	switch {
+	case j.Day == 1:
+		return RomanDate{j.Year, j.Month, RomanKalends, 1, false}
+	case j.Day <= romanNones(j.Month):
+		return RomanDate{j.Year, j.Month, RomanNones, romanNones(j.Month) - j.Day + 1, false}
+	case j.Day <= romanIdes(j.Month):
+		return RomanDate{j.Year, j.Month, RomanIdes, romanIdes(j.Month) - j.Day + 1, false}
+	case j.Month != february || mod(j.Year, 4) != 0:
+		return RomanDate{nextYear, nextMonth, RomanKalends, kalends - absoluteDate + 1, false}
+	case j.Day < 25:
+		return RomanDate{j.Year, march, RomanKalends, 30 - j.Day, false}
+	default:
+		return RomanDate{j.Year, march, RomanKalends, 31 - j.Day, j.Day == 25}
+	}
+}
+
+// RomanFromJulian returns the Roman date of a given Julian date.
+func RomanFromJulian(d JulianDate) RomanDate {
+	return RomanFromAbsolute(AbsoluteFromJulian(d))
+}
+
+// JulianFromRoman returns the Julian date of a given Roman date.
+func JulianFromRoman(d RomanDate) JulianDate {
+	return JulianFromAbsolute(AbsoluteFromRoman(d))
+}
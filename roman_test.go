@@ -0,0 +1,99 @@
+// Copyright (C) 2022  Alexander Staudt
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package libcalendar
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestRomanFromJulian(t *testing.T) {
+	var tests = []struct {
+		julian JulianDate
+		want   RomanDate
+		name   string
+	}{
+		{JulianDate{-586, 7, 30}, RomanDate{-586, 8, RomanKalends, 3, false}, "ante diem III Kalendas Augustas 167 a.u.c."},
+		{JulianDate{-43, 3, 15}, RomanDate{-43, 3, RomanIdes, 1, false}, "Idibus Martiis 710 a.u.c."},
+		{JulianDate{-43, 3, 14}, RomanDate{-43, 3, RomanIdes, 2, false}, "pridie Idus Martias 710 a.u.c."},
+		{JulianDate{2022, 3, 5}, RomanDate{2022, 3, RomanNones, 3, false}, "ante diem III Nonas Martias 2775 a.u.c."},
+		{JulianDate{2022, 1, 5}, RomanDate{2022, 1, RomanNones, 1, false}, "Nonis Ianuariis 2775 a.u.c."},
+		{JulianDate{2023, 12, 14}, RomanDate{2024, 1, RomanKalends, 19, false}, "ante diem XIX Kalendas Ianuarias 2777 a.u.c."},
+		{JulianDate{2023, 2, 24}, RomanDate{2023, 3, RomanKalends, 6, false}, "ante diem VI Kalendas Martias 2776 a.u.c."},
+		{JulianDate{2024, 2, 24}, RomanDate{2024, 3, RomanKalends, 6, false}, "ante diem VI Kalendas Martias 2777 a.u.c."},
+		{JulianDate{2024, 2, 25}, RomanDate{2024, 3, RomanKalends, 6, true}, "ante diem bis VI Kalendas Martias 2777 a.u.c."},
+		{JulianDate{2024, 2, 26}, RomanDate{2024, 3, RomanKalends, 5, false}, "ante diem V Kalendas Martias 2777 a.u.c."},
+		{JulianDate{2024, 2, 14}, RomanDate{2024, 3, RomanKalends, 16, false}, "ante diem XVI Kalendas Martias 2777 a.u.c."},
+	}
+	for _, tt := range tests {
+		testname := fmt.Sprintf("%v", tt.julian)
+		t.Run(testname, func(t *testing.T) {
+			got := RomanFromJulian(tt.julian)
+			t.Logf("got %v (%v), want %v (%v)", got, got.Date().Components, tt.name, tt.want)
+			if got != tt.want || got.String() != tt.name {
+				t.Errorf("got %v (%v), want %v (%v)", got, got.Date().Components, tt.name, tt.want)
+			}
+			if j := JulianFromRoman(got); j != tt.julian {
+				t.Errorf("got %v, want %v", j, tt.julian)
+			}
+			parsed, err := ParseRomanDate(tt.name)
+			if parsed != tt.want || err != nil {
+				t.Errorf("got %v (%v), want %v", parsed, err, tt.want)
+			}
+		})
+	}
+
+	t.Run("round trip", func(t *testing.T) {
+		from := AbsoluteFromJulian(JulianDate{-100, 1, 1})
+		for rd := from; rd < from+365*12; rd++ {
+			if d := AbsoluteFromRoman(RomanFromAbsolute(rd)); d != rd {
+				t.Fatalf("got %v, want %v", d, rd)
+			}
+		}
+	})
+}
+
+func TestParseRomanDate(t *testing.T) {
+	var tests = []struct {
+		s    string
+		want RomanDate
+		ok   bool
+	}{
+		{"a.d. iii non. mart. 2775", RomanDate{}, false},
+		{"a.d. III Nonas Martias MMDCCLXXV", RomanDate{2022, 3, RomanNones, 3, false}, true},
+		{"KALENDIS IANUARIIS 2775", RomanDate{2022, 1, RomanKalends, 1, false}, true},
+		{"ante diem bis VI Kalendas Martias 2776 a.u.c.", RomanDate{}, false}, // not a leap year
+		{"ante diem IX Nonas Martias 2775 a.u.c.", RomanDate{}, false},
+		{"pridie Kalendis Martiis 2775", RomanDate{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			got, err := ParseRomanDate(tt.s)
+			t.Logf("got %v (%v), want %v", got, err, tt.want)
+			if got != tt.want || (err == nil) != tt.ok {
+				t.Errorf("got %v (%v), want %v", got, err, tt.want)
+			}
+		})
+	}
+
+	t.Run("ParseDate", func(t *testing.T) {
+		d, err := ParseDate("Idibus Martiis 710", "roman", FormatOptions{})
+		want := AbsoluteFromJulian(JulianDate{-43, 3, 15})
+		if err != nil || AbsoluteFromDate(d) != want || d.Weekday != "dies Mercurii" {
+			t.Errorf("got %v %v (%v), want %v", AbsoluteFromDate(d), d.Weekday, err, want)
+		}
+	})
+}
@@ -0,0 +1,118 @@
+// Copyright (C) 2022  Alexander Staudt
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// This file implements the times of candle lighting and havdalah marking the
+// beginning and end of Shabbat and the Jewish festivals.
+
+package libcalendar
+
+// HavdalahRule specifies nightfall (tzeit), when Shabbat and festivals end:
+// either a fixed number of minutes after sunset or the moment at which the
+// sun is a given number of degrees below the horizon. The zero value
+// denotes 8.5 degrees.
+type HavdalahRule struct {
+	Minutes float64 // minutes after sunset, used if not zero
+	Degrees float64 // depression of the sun, defaults to 8.5
+}
+
+// CandleLighting returns the moment, in standard time, of candle lighting on
+// a given absolute date at a location, a number of minutes before sunset. It
+// returns false if the sun does not set on that date.
+func CandleLighting(absoluteDate float64, l Location, minutesBefore float64) (Moment, bool) {
+	sunset, ok := Sunset(absoluteDate, l)
+	if !ok {
+		return 0, false
+	}
+	return sunset - Moment(minutesBefore/1440), true
+}
+
+// Havdalah returns the moment, in standard time, of nightfall on a given
+// absolute date at a location, according to rule. It returns false if the
+// sun does not set or reach the depression of the rule on that date.
+func Havdalah(absoluteDate float64, l Location, rule HavdalahRule) (Moment, bool) {
+	if rule.Minutes != 0 {
+		sunset, ok := Sunset(absoluteDate, l)
+		if !ok {
+			return 0, false
+		}
+		return sunset + Moment(rule.Minutes/1440), true
+	}
+	if rule.Degrees == 0 {
+		rule.Degrees = 8.5
+	}
+	return Dusk(absoluteDate, l, rule.Degrees)
+}
+
+// ScheduleOptions control the times listed by ShabbatSchedule.
+type ScheduleOptions struct {
+	CandleLightingMinutes float64      // minutes before sunset, defaults to 18
+	Havdalah              HavdalahRule // nightfall ending Shabbat and festivals
+	Diaspora              bool         // observe the second festival days
+}
+
+// ScheduleEntry is a candle-lighting or havdalah time.
+type ScheduleEntry struct {
+	Name     string  // "Candle lighting" or "Havdalah"
+	Occasion string  // "Shabbat" or the festival beginning or ending
+	Date     float64 // absolute date
+	Time     Moment  // standard time
+}
+
+// restDay returns the name of Shabbat or the festival on a given absolute
+// date, festivals taking precedence.
+func restDay(absoluteDate float64, diaspora bool) (string, bool) {
+	if name, ok := YomTovName(absoluteDate, diaspora); ok {
+		return name, true
+	}
+	if DayOfWeek(absoluteDate) == Saturday {
+		return "Shabbat", true
+	}
+	return "", false
+}
+
+// ShabbatSchedule returns the candle-lighting and havdalah times of the
+// (Sunday to Saturday) week containing a given absolute date at a location.
+// Candles are lit before sunset on the eve of Shabbat and festivals, but
+// after nightfall when a festival follows Shabbat or another festival day. Havdalah is
+// listed on the last of consecutive days of rest. Times are omitted where the
+// sun does not set.
+func ShabbatSchedule(absoluteDate float64, l Location, opts ScheduleOptions) []ScheduleEntry {
+	if opts.CandleLightingMinutes == 0 {
+		opts.CandleLightingMinutes = 18
+	}
+	sunday := KDayOnOrBefore(absoluteDate, float64(Sunday))
+	entries := []ScheduleEntry{}
+	for date := sunday; date < sunday+7; date++ {
+		today, rest := restDay(date, opts.Diaspora)
+		tomorrow, restTomorrow := restDay(date+1, opts.Diaspora)
+		var t Moment
+		var ok bool
+		switch {
+		case restTomorrow && rest && tomorrow != "Shabbat":
+			t, ok = Havdalah(date, l, opts.Havdalah)
+		case restTomorrow:
+			t, ok = CandleLighting(date, l, opts.CandleLightingMinutes)
+		}
+		if ok {
+			entries = append(entries, ScheduleEntry{"Candle lighting", tomorrow, date, t})
+		}
+		if rest && !restTomorrow {
+			if t, ok := Havdalah(date, l, opts.Havdalah); ok {
+				entries = append(entries, ScheduleEntry{"Havdalah", today, date, t})
+			}
+		}
+	}
+	return entries
+}
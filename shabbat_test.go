@@ -0,0 +1,111 @@
+// Copyright (C) 2022  Alexander Staudt
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package libcalendar
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// formatEntries formats schedule entries as "name, occasion, date time".
+func formatEntries(entries []ScheduleEntry) []string {
+	s := []string{}
+	for _, e := range entries {
+		s = append(s, fmt.Sprintf("%s, %s, %v %s", e.Name, e.Occasion, GregorianFromAbsolute(e.Date), e.Time.Time().Format("15:04")))
+	}
+	return s
+}
+
+func TestCandleLightingHavdalah(t *testing.T) {
+	newYork, _ := time.LoadLocation("America/New_York")
+	l := Location{Latitude: 40.7128, Longitude: -74.0060, TimeZone: newYork}
+	friday := AbsoluteFromGregorian(GregorianDate{2022, 6, 17})
+	clock := func(m Moment, ok bool) string {
+		if !ok {
+			return ""
+		}
+		return m.Time().Format("15:04")
+	}
+	var tests = []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"18 minutes", clock(CandleLighting(friday, l, 18)), "20:11"},
+		{"40 minutes", clock(CandleLighting(friday, l, 40)), "19:49"},
+		{"8.5 degrees", clock(Havdalah(friday+1, l, HavdalahRule{})), "21:20"},
+		{"50 minutes", clock(Havdalah(friday+1, l, HavdalahRule{Minutes: 50})), "21:20"},
+		{"72 minutes", clock(Havdalah(friday+1, l, HavdalahRule{Minutes: 72})), "21:42"},
+		{"polar day", clock(CandleLighting(friday, Location{Latitude: 78.2, Longitude: 15.6, Zone: 2}, 18)), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Logf("got %v, want %v", tt.got, tt.want)
+			if tt.got != tt.want {
+				t.Errorf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestShabbatSchedule(t *testing.T) {
+	newYork, _ := time.LoadLocation("America/New_York")
+	l := Location{Latitude: 40.7128, Longitude: -74.0060, TimeZone: newYork}
+	var tests = []struct {
+		date     GregorianDate
+		diaspora bool
+		want     []string
+	}{
+		{GregorianDate{2022, 6, 15}, true, []string{
+			"Candle lighting, Shabbat, 17 June 2022 20:11",
+			"Havdalah, Shabbat, 18 June 2022 21:20",
+		}},
+		{GregorianDate{2022, 9, 25}, true, []string{
+			"Candle lighting, Rosh Hashanah, 25 September 2022 18:30",
+			"Candle lighting, Rosh Hashanah, 26 September 2022 19:26",
+			"Havdalah, Rosh Hashanah, 27 September 2022 19:25",
+			"Candle lighting, Shabbat, 30 September 2022 18:21",
+			"Havdalah, Shabbat, 1 October 2022 19:18",
+		}},
+		{GregorianDate{2022, 10, 16}, false, []string{
+			"Candle lighting, Shemini Atzeret, 16 October 2022 17:56",
+			"Havdalah, Shemini Atzeret, 17 October 2022 18:53",
+			"Candle lighting, Shabbat, 21 October 2022 17:48",
+			"Havdalah, Shabbat, 22 October 2022 18:46",
+		}},
+		{GregorianDate{2022, 4, 11}, true, []string{ // Passover begins on Shabbat
+			"Candle lighting, Passover, 15 April 2022 19:17",
+			"Candle lighting, Passover, 16 April 2022 20:19",
+		}},
+		{GregorianDate{2023, 4, 5}, true, []string{ // Shabbat follows Passover
+			"Candle lighting, Passover, 5 April 2023 19:06",
+			"Candle lighting, Passover, 6 April 2023 20:07",
+			"Candle lighting, Shabbat, 7 April 2023 19:08",
+			"Havdalah, Shabbat, 8 April 2023 20:09",
+		}},
+	}
+	for _, tt := range tests {
+		testname := fmt.Sprintf("%v %v", tt.date, tt.diaspora)
+		t.Run(testname, func(t *testing.T) {
+			ans := formatEntries(ShabbatSchedule(AbsoluteFromGregorian(tt.date), l, ScheduleOptions{Diaspora: tt.diaspora}))
+			t.Logf("got %q, want %q", ans, tt.want)
+			if fmt.Sprint(ans) != fmt.Sprint(tt.want) {
+				t.Errorf("got %q, want %q", ans, tt.want)
+			}
+		})
+	}
+}
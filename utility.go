@@ -16,6 +16,7 @@
 package libcalendar
 
 import (
+	"errors"
 	"fmt"
 	"math"
 	"sort"
@@ -28,14 +29,26 @@ import (
 //  - "gregorian"
 //  - "iso"
 //  - "julian"
+//  - "roman"
 //  - "islamic"
 //  - "hebrew"
 //  - "mayanLongCount"
 //  - "mayanHaab"
 //  - "mayanTzolkin"
+//  - "aztecXihuitl"
+//  - "aztecTonalpohualli"
+//  - "baliPawukon"
 //  - "french"
+//  - "egyptian"
+//  - "armenian"
 //  - "oldHinduSolar"
 //  - "oldHinduLunar"
+//  - "hinduSolar"
+//  - "hinduLunar"
+//  - "bahai"
+//  - "westernBahai"
+//  - "julianDay", "modifiedJulianDay", "unixDay", "excelSerial", "lilianDay"
+//    and "rataDie" (day counts)
 //
 // For more information about these calendars, see:
 //
@@ -51,6 +64,8 @@ func FromAbsolute(absoluteDate float64, calendar string) string {
 		return fmt.Sprint(GregorianFromAbsolute(absoluteDate))
 	case "julian":
 		return fmt.Sprint(JulianFromAbsolute(absoluteDate))
+	case "roman":
+		return fmt.Sprint(RomanFromAbsolute(absoluteDate))
 	case "iso":
 		return fmt.Sprint(IsoFromAbsolute(absoluteDate))
 	case "islamic":
@@ -63,19 +78,74 @@ func FromAbsolute(absoluteDate float64, calendar string) string {
 		return fmt.Sprint(MayanHaabFromAbsolute(absoluteDate))
 	case "mayanTzolkin":
 		return fmt.Sprint(MayanTzolkinFromAbsolute(absoluteDate))
+	case "aztecXihuitl":
+		return fmt.Sprint(AztecXihuitlFromAbsolute(absoluteDate))
+	case "aztecTonalpohualli":
+		return fmt.Sprint(AztecTonalpohualliFromAbsolute(absoluteDate))
+	case "baliPawukon":
+		return fmt.Sprint(BaliPawukonFromAbsolute(absoluteDate))
 	case "french":
 		return fmt.Sprint(FrenchFromAbsolute(absoluteDate))
+	case "egyptian":
+		return fmt.Sprint(EgyptianFromAbsolute(absoluteDate))
+	case "armenian":
+		return fmt.Sprint(ArmenianFromAbsolute(absoluteDate))
 	case "oldHinduSolar":
 		return fmt.Sprint(OldHinduSolarFromAbsolute(absoluteDate))
 	case "oldHinduLunar":
 		return fmt.Sprint(OldHinduLunarFromAbsolute(absoluteDate))
+	case "hinduSolar":
+		return fmt.Sprint(HinduSolarFromAbsolute(absoluteDate))
+	case "hinduLunar":
+		return fmt.Sprint(HinduLunarFromAbsolute(absoluteDate))
+	case "bahai":
+		return fmt.Sprint(BahaiFromAbsolute(absoluteDate))
+	case "westernBahai":
+		return fmt.Sprint(WesternBahaiFromAbsolute(absoluteDate))
 	default:
+		if c, ok := dayCounts[calendar]; ok {
+			return dayCountString(calendar, c.fromAbsolute(absoluteDate))
+		}
 		return ""
 	}
 }
 
 // Utilities for a more generic approach to converting dates
 
+// Calendars returns the names of the supported calendars, as used by
+// FromAbsolute, DateFromAbsolute and Date.
+func Calendars() []string {
+	return []string{
+		"gregorian",
+		"iso",
+		"julian",
+		"roman",
+		"islamic",
+		"hebrew",
+		"mayanLongCount",
+		"mayanHaab",
+		"mayanTzolkin",
+		"aztecXihuitl",
+		"aztecTonalpohualli",
+		"baliPawukon",
+		"french",
+		"egyptian",
+		"armenian",
+		"oldHinduSolar",
+		"oldHinduLunar",
+		"hinduSolar",
+		"hinduLunar",
+		"bahai",
+		"westernBahai",
+		"julianDay",
+		"modifiedJulianDay",
+		"unixDay",
+		"excelSerial",
+		"lilianDay",
+		"rataDie",
+	}
+}
+
 // Date represents a generic container for dates, holding information about
 // arbitrary dates. Possible calendar names are:
 type Date struct {
@@ -83,18 +153,17 @@ type Date struct {
 	Components     []float64 `json:"components"`     // e.g. []float64{2022, 05, 28}
 	ComponentNames []string  `json:"componentNames"` // e.g. []string{"year", "month", "day"}
 	MonthNames     []string  `json:"monthNames"`     // e.g. []string{"January", ..., "December"}
+	Weekday        string    `json:"weekday"`        // e.g. "Wednesday", empty for calendars without weeks
 }
 
 // jsonFromNumSlice returns a JSON array from a slice of float64
 func jsonFromNumSlice(s []float64) string {
 	n := len(s)
 	switch {
-	case n == 1:
-		return fmt.Sprint(s[0])
-	case n > 1:
-		elems := fmt.Sprint(s[0])
+	case n > 0:
+		elems := formatFloat(s[0])
 		for i := 1; i < n; i++ {
-			elems = elems + "," + fmt.Sprint(s[i])
+			elems = elems + "," + formatFloat(s[i])
 		}
 		return fmt.Sprintf("[%s]", elems)
 	default: // empty array, i.e. n == 0:
@@ -106,9 +175,7 @@ func jsonFromNumSlice(s []float64) string {
 func jsonFromStringSlice(s []string) string {
 	n := len(s)
 	switch {
-	case n == 1:
-		return fmt.Sprint(s[0])
-	case n > 1:
+	case n > 0:
 		elems := fmt.Sprintf("\"%s\"", s[0])
 		for i := 1; i < n; i++ {
 			elems = elems + "," + fmt.Sprintf("\"%s\"", s[i])
@@ -129,12 +196,15 @@ func (d Date) Json() string {
 		fmt.Sprintf("\"componentNames\":%s", jsonFromStringSlice(d.ComponentNames))
 	monthNames :=
 		fmt.Sprintf("\"monthNames\":%s", jsonFromStringSlice(d.MonthNames))
+	weekday :=
+		fmt.Sprintf("\"weekday\":\"%s\"", d.Weekday)
 	return fmt.Sprintf(
-		"{%s,%s,%s,%s}",
+		"{%s,%s,%s,%s,%s}",
 		calendar,
 		components,
 		componentNames,
 		monthNames,
+		weekday,
 	)
 }
 
@@ -151,6 +221,8 @@ func (d Date) String() string {
 		return fmt.Sprint(isoFromDate(d))
 	case "julian":
 		return fmt.Sprint(gregorianFromDate(d))
+	case "roman":
+		return fmt.Sprint(romanFromDate(d))
 	case "islamic":
 		return fmt.Sprint(islamicFromDate(d))
 	case "hebrew":
@@ -161,17 +233,53 @@ func (d Date) String() string {
 		return fmt.Sprint(mayanHaabFromDate(d))
 	case "mayanTzolkin":
 		return fmt.Sprint(mayanTzolkinFromDate(d))
+	case "aztecXihuitl":
+		return fmt.Sprint(aztecXihuitlFromDate(d))
+	case "aztecTonalpohualli":
+		return fmt.Sprint(aztecTonalpohualliFromDate(d))
+	case "baliPawukon":
+		return fmt.Sprint(baliPawukonFromDate(d))
 	case "french":
 		return fmt.Sprint(frenchFromDate(d))
+	case "egyptian":
+		return fmt.Sprint(egyptianFromDate(d))
+	case "armenian":
+		return fmt.Sprint(armenianFromDate(d))
 	case "oldHinduSolar":
 		return fmt.Sprint(oldHinduSolarFromDate(d))
 	case "oldHinduLunar":
 		return fmt.Sprint(oldHinduLunarFromDate(d))
+	case "hinduSolar":
+		return fmt.Sprint(hinduSolarFromDate(d))
+	case "hinduLunar":
+		return fmt.Sprint(hinduLunarFromDate(d))
+	case "bahai", "westernBahai":
+		return fmt.Sprint(bahaiFromDate(d))
 	default:
+		if _, ok := dayCounts[d.Calendar]; ok && len(d.Components) == 1 {
+			return dayCountString(d.Calendar, d.Components[0])
+		}
 		return ""
 	}
 }
 
+// Year returns the year of a Date: for the Bahá'í calendars, the year of the
+// Bahá'í Era rather than the major cycle. For calendars without years, e.g.
+// the Mayan and Aztec cycles or day counts, ok is false.
+func (d Date) Year() (year float64, ok bool) {
+	switch d.Calendar {
+	case "bahai", "westernBahai":
+		_, _, year, ok = d.dayMonthYear()
+		return year, ok
+	case "mayanLongCount", "mayanHaab", "mayanTzolkin", "aztecXihuitl", "aztecTonalpohualli", "baliPawukon":
+		return 0, false
+	}
+	if _, isDayCount := dayCounts[d.Calendar]; isDayCount || !isValidCalendar(d.Calendar) || len(d.Components) == 0 {
+		return 0, false
+	}
+	return d.Components[0], true
+}
+
 // values returns the values of map[float64]string x as a slice of type string, sorted
 // by the keys.
 func values(x map[float64]string) []string {
@@ -232,6 +340,7 @@ func (d GregorianDate) Date() Date {
 			"year", "month", "day",
 		},
 		MonthNames: values(gregorianMonths),
+		Weekday:    d.WeekdayName(),
 	}
 }
 
@@ -248,6 +357,7 @@ func (d JulianDate) Date() Date {
 			"year", "month", "day",
 		},
 		MonthNames: values(gregorianMonths),
+		Weekday:    d.WeekdayName(),
 	}
 }
 
@@ -264,6 +374,7 @@ func (d IsoDate) Date() Date {
 			"year", "week", "day",
 		},
 		MonthNames: []string{},
+		Weekday:    d.WeekdayName(),
 	}
 }
 
@@ -280,6 +391,7 @@ func (d IslamicDate) Date() Date {
 			"year", "month", "day",
 		},
 		MonthNames: values(islamicMonths),
+		Weekday:    d.WeekdayName(),
 	}
 }
 
@@ -296,6 +408,7 @@ func (d HebrewDate) Date() Date {
 			"year", "month", "day",
 		},
 		MonthNames: HebrewMonthNames(d),
+		Weekday:    d.WeekdayName(),
 	}
 }
 
@@ -326,6 +439,7 @@ func (d MayanLongCount) Date() Date {
 			"baktun", "katun", "tun", "uinal", "kin",
 		},
 		MonthNames: []string{},
+		Weekday:    d.WeekdayName(),
 	}
 }
 
@@ -341,6 +455,7 @@ func (d MayanHaabDate) Date() Date {
 			"day", "month",
 		},
 		MonthNames: values(mayanHaabMonths),
+		Weekday:    d.WeekdayName(),
 	}
 }
 
@@ -356,6 +471,65 @@ func (d MayanTzolkinDate) Date() Date {
 			"number", "name",
 		},
 		MonthNames: values(mayanTzolkinNames),
+		Weekday:    d.WeekdayName(),
+	}
+}
+
+// Date() creates a Date from its receiver.
+func (d AztecXihuitlDate) Date() Date {
+	return Date{
+		Calendar: "aztecXihuitl",
+		Components: []float64{
+			d.Day,
+			d.Month,
+		},
+		ComponentNames: []string{
+			"day", "month",
+		},
+		MonthNames: values(aztecXihuitlMonths),
+		Weekday:    d.WeekdayName(),
+	}
+}
+
+// Date() creates a Date from its receiver.
+func (d AztecTonalpohualliDate) Date() Date {
+	return Date{
+		Calendar: "aztecTonalpohualli",
+		Components: []float64{
+			d.Number,
+			d.Name,
+		},
+		ComponentNames: []string{
+			"number", "name",
+		},
+		MonthNames: values(aztecTonalpohualliNames),
+		Weekday:    d.WeekdayName(),
+	}
+}
+
+// Date() creates a Date from its receiver. Luang is represented by a
+// component of value 1.
+func (d BaliPawukonDate) Date() Date {
+	return Date{
+		Calendar: "baliPawukon",
+		Components: []float64{
+			flag(d.Luang),
+			d.Dwiwara,
+			d.Triwara,
+			d.Caturwara,
+			d.Pancawara,
+			d.Sadwara,
+			d.Saptawara,
+			d.Asatawara,
+			d.Sangawara,
+			d.Dasawara,
+		},
+		ComponentNames: []string{
+			"luang", "dwiwara", "triwara", "caturwara", "pancawara",
+			"sadwara", "saptawara", "asatawara", "sangawara", "dasawara",
+		},
+		MonthNames: []string{},
+		Weekday:    d.WeekdayName(),
 	}
 }
 
@@ -372,6 +546,63 @@ func (d FrenchDate) Date() Date {
 			"year", "month", "day",
 		},
 		MonthNames: values(frenchMonths),
+		Weekday:    d.WeekdayName(),
+	}
+}
+
+// Date() creates a Date from its receiver. The bissextile day is marked by a
+// leap component of value 1.
+func (d RomanDate) Date() Date {
+	return Date{
+		Calendar: "roman",
+		Components: []float64{
+			d.Year,
+			d.Month,
+			d.Event,
+			d.Count,
+			flag(d.Leap),
+		},
+		ComponentNames: []string{
+			"year", "month", "event", "count", "leap",
+		},
+		MonthNames: values(romanMonths),
+		Weekday:    d.WeekdayName(),
+	}
+}
+
+// Date() creates a Date from its receiver. Month 13 denotes the epagomenal
+// days.
+func (d EgyptianDate) Date() Date {
+	return Date{
+		Calendar: "egyptian",
+		Components: []float64{
+			d.Year,
+			d.Month,
+			d.Day,
+		},
+		ComponentNames: []string{
+			"year", "month", "day",
+		},
+		MonthNames: values(egyptianMonths),
+		Weekday:    d.WeekdayName(),
+	}
+}
+
+// Date() creates a Date from its receiver. Month 13 denotes the epagomenal
+// days.
+func (d ArmenianDate) Date() Date {
+	return Date{
+		Calendar: "armenian",
+		Components: []float64{
+			d.Year,
+			d.Month,
+			d.Day,
+		},
+		ComponentNames: []string{
+			"year", "month", "day",
+		},
+		MonthNames: values(armenianMonths),
+		Weekday:    d.WeekdayName(),
 	}
 }
 
@@ -388,6 +619,7 @@ func (d OldHinduSolarDate) Date() Date {
 			"year", "month", "day",
 		},
 		MonthNames: values(hinduSolarMonths),
+		Weekday:    d.WeekdayName(),
 	}
 }
 
@@ -404,9 +636,83 @@ func (d OldHinduLunarDate) Date() Date {
 			"year", "month", "day",
 		},
 		MonthNames: values(hinduLunarMonths),
+		Weekday:    d.WeekdayName(),
+	}
+}
+
+// Date() creates a Date from its receiver.
+func (d HinduSolarDate) Date() Date {
+	return Date{
+		Calendar: "hinduSolar",
+		Components: []float64{
+			d.Year,
+			d.Month,
+			d.Day,
+		},
+		ComponentNames: []string{
+			"year", "month", "day",
+		},
+		MonthNames: values(hinduSolarMonths),
+		Weekday:    d.WeekdayName(),
+	}
+}
+
+// Date() creates a Date from its receiver. Leap months and days are marked by
+// components of value 1.
+func (d HinduLunarDate) Date() Date {
+	return Date{
+		Calendar: "hinduLunar",
+		Components: []float64{
+			d.Year,
+			d.Month,
+			d.Day,
+			flag(d.LeapMonth),
+			flag(d.LeapDay),
+		},
+		ComponentNames: []string{
+			"year", "month", "day", "leapMonth", "leapDay",
+		},
+		MonthNames: values(hinduLunarMonths),
+		Weekday:    d.WeekdayName(),
+	}
+}
+
+// Date() creates a Date from its receiver. Month 0 denotes Ayyám-i-Há.
+func (d BahaiDate) Date() Date {
+	return Date{
+		Calendar: "bahai",
+		Components: []float64{
+			d.Major,
+			d.Cycle,
+			d.Year,
+			d.Month,
+			d.Day,
+		},
+		ComponentNames: []string{
+			"major", "cycle", "year", "month", "day",
+		},
+		MonthNames: values(bahaiMonths),
+		Weekday:    d.WeekdayName(),
 	}
 }
 
+// westernBahaiDate creates a Date of the arithmetic ("westernBahai") Bahá'í
+// calendar from a given BahaiDate.
+func westernBahaiDate(d BahaiDate) Date {
+	date := d.Date()
+	date.Calendar = "westernBahai"
+	date.Weekday = LocalizedWeekdayName("westernBahai", AbsoluteFromWesternBahai(d), RootLocale)
+	return date
+}
+
+// flag returns 1 if b is true, and 0 otherwise.
+func flag(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
 // gregorianFromDate computes a GregorianDate from a given libcalendar Date.
 func gregorianFromDate(d Date) GregorianDate {
 	return GregorianDate{
@@ -481,6 +787,58 @@ func mayanTzolkinFromDate(d Date) MayanTzolkinDate {
 	}
 }
 
+// aztecXihuitlFromDate computes an AztecXihuitlDate from a given libcalendar
+// Date.
+func aztecXihuitlFromDate(d Date) AztecXihuitlDate {
+	return AztecXihuitlDate{
+		Day:   d.Components[0],
+		Month: d.Components[1],
+	}
+}
+
+// aztecTonalpohualliFromDate computes an AztecTonalpohualliDate from a given
+// libcalendar Date.
+func aztecTonalpohualliFromDate(d Date) AztecTonalpohualliDate {
+	return AztecTonalpohualliDate{
+		Number: d.Components[0],
+		Name:   d.Components[1],
+	}
+}
+
+// baliPawukonFromDate computes a BaliPawukonDate from a given libcalendar
+// Date.
+func baliPawukonFromDate(d Date) BaliPawukonDate {
+	return BaliPawukonDate{
+		Luang:     d.Components[0] == 1,
+		Dwiwara:   d.Components[1],
+		Triwara:   d.Components[2],
+		Caturwara: d.Components[3],
+		Pancawara: d.Components[4],
+		Sadwara:   d.Components[5],
+		Saptawara: d.Components[6],
+		Asatawara: d.Components[7],
+		Sangawara: d.Components[8],
+		Dasawara:  d.Components[9],
+	}
+}
+
+// bahaiFromDate computes a BahaiDate from a given libcalendar Date. Dates of
+// three components are read as year of the Bahá'í Era, month and day.
+func bahaiFromDate(d Date) BahaiDate {
+	if len(d.Components) == 3 {
+		b := bahaiFromYear(d.Components[0])
+		b.Month, b.Day = d.Components[1], d.Components[2]
+		return b
+	}
+	return BahaiDate{
+		Major: d.Components[0],
+		Cycle: d.Components[1],
+		Year:  d.Components[2],
+		Month: d.Components[3],
+		Day:   d.Components[4],
+	}
+}
+
 // frenchFromDate computes a FrenchDate from a given libcalendar Date.
 func frenchFromDate(d Date) FrenchDate {
 	return FrenchDate{
@@ -490,6 +848,35 @@ func frenchFromDate(d Date) FrenchDate {
 	}
 }
 
+// romanFromDate computes a RomanDate from a given libcalendar Date.
+func romanFromDate(d Date) RomanDate {
+	return RomanDate{
+		Year:  d.Components[0],
+		Month: d.Components[1],
+		Event: d.Components[2],
+		Count: d.Components[3],
+		Leap:  d.Components[4] == 1,
+	}
+}
+
+// egyptianFromDate computes an EgyptianDate from a given libcalendar Date.
+func egyptianFromDate(d Date) EgyptianDate {
+	return EgyptianDate{
+		Year:  d.Components[0],
+		Month: d.Components[1],
+		Day:   d.Components[2],
+	}
+}
+
+// armenianFromDate computes an ArmenianDate from a given libcalendar Date.
+func armenianFromDate(d Date) ArmenianDate {
+	return ArmenianDate{
+		Year:  d.Components[0],
+		Month: d.Components[1],
+		Day:   d.Components[2],
+	}
+}
+
 // oldHinduSolarFromDate computes a OldHinduSolarDate from a given
 // libcalendar Date.
 func oldHinduSolarFromDate(d Date) OldHinduSolarDate {
@@ -510,6 +897,29 @@ func oldHinduLunarFromDate(d Date) OldHinduLunarDate {
 	}
 }
 
+// hinduSolarFromDate computes a HinduSolarDate from a given libcalendar Date.
+func hinduSolarFromDate(d Date) HinduSolarDate {
+	return HinduSolarDate{
+		Year:  d.Components[0],
+		Month: d.Components[1],
+		Day:   d.Components[2],
+	}
+}
+
+// hinduLunarFromDate computes a HinduLunarDate from a given libcalendar Date.
+// The leap month and leap day components are optional.
+func hinduLunarFromDate(d Date) HinduLunarDate {
+	h := HinduLunarDate{
+		Year:  d.Components[0],
+		Month: d.Components[1],
+		Day:   d.Components[2],
+	}
+	if len(d.Components) == 5 {
+		h.LeapMonth, h.LeapDay = d.Components[3] == 1, d.Components[4] == 1
+	}
+	return h
+}
+
 // AbsoluteFromDate returns the absolute (fixed) date from a given calendar
 // date. Note that no checks are performed as to whether the given date is
 // valid, i.e. in the date range for which the calendar was defined. For
@@ -524,6 +934,8 @@ func AbsoluteFromDate(d Date) (absoluteDate float64) {
 		return AbsoluteFromIso(isoFromDate(d))
 	case "julian":
 		return AbsoluteFromJulian(julianFromDate(d))
+	case "roman":
+		return AbsoluteFromRoman(romanFromDate(d))
 	case "islamic":
 		return AbsoluteFromIslamic(islamicFromDate(d))
 	case "hebrew":
@@ -532,11 +944,26 @@ func AbsoluteFromDate(d Date) (absoluteDate float64) {
 		return AbsoluteFromMayanLongCount(mayanLongCountFromDate(d))
 	case "french":
 		return AbsoluteFromFrench(frenchFromDate(d))
+	case "egyptian":
+		return AbsoluteFromEgyptian(egyptianFromDate(d))
+	case "armenian":
+		return AbsoluteFromArmenian(armenianFromDate(d))
 	case "oldHinduSolar":
 		return AbsoluteFromOldHinduSolar(oldHinduSolarFromDate(d))
 	case "oldHinduLunar":
 		return AbsoluteFromOldHinduLunar(oldHinduLunarFromDate(d))
+	case "hinduSolar":
+		return AbsoluteFromHinduSolar(hinduSolarFromDate(d))
+	case "hinduLunar":
+		return AbsoluteFromHinduLunar(hinduLunarFromDate(d))
+	case "bahai":
+		return AbsoluteFromBahai(bahaiFromDate(d))
+	case "westernBahai":
+		return AbsoluteFromWesternBahai(bahaiFromDate(d))
 	default:
+		if c, ok := dayCounts[d.Calendar]; ok && len(d.Components) == 1 {
+			return c.toAbsolute(d.Components[0])
+		}
 		return math.NaN()
 	}
 }
@@ -551,6 +978,8 @@ func DateFromAbsolute(absoluteDate float64, calendar string) Date {
 		return IsoFromAbsolute(absoluteDate).Date()
 	case "julian":
 		return JulianFromAbsolute(absoluteDate).Date()
+	case "roman":
+		return RomanFromAbsolute(absoluteDate).Date()
 	case "islamic":
 		return IslamicFromAbsolute(absoluteDate).Date()
 	case "hebrew":
@@ -561,17 +990,214 @@ func DateFromAbsolute(absoluteDate float64, calendar string) Date {
 		return MayanHaabFromAbsolute(absoluteDate).Date()
 	case "mayanTzolkin":
 		return MayanTzolkinFromAbsolute(absoluteDate).Date()
+	case "aztecXihuitl":
+		return AztecXihuitlFromAbsolute(absoluteDate).Date()
+	case "aztecTonalpohualli":
+		return AztecTonalpohualliFromAbsolute(absoluteDate).Date()
+	case "baliPawukon":
+		return BaliPawukonFromAbsolute(absoluteDate).Date()
 	case "french":
 		return FrenchFromAbsolute(absoluteDate).Date()
+	case "egyptian":
+		return EgyptianFromAbsolute(absoluteDate).Date()
+	case "armenian":
+		return ArmenianFromAbsolute(absoluteDate).Date()
 	case "oldHinduSolar":
 		return OldHinduSolarFromAbsolute(absoluteDate).Date()
 	case "oldHinduLunar":
 		return OldHinduLunarFromAbsolute(absoluteDate).Date()
+	case "hinduSolar":
+		return HinduSolarFromAbsolute(absoluteDate).Date()
+	case "hinduLunar":
+		return HinduLunarFromAbsolute(absoluteDate).Date()
+	case "bahai":
+		return BahaiFromAbsolute(absoluteDate).Date()
+	case "westernBahai":
+		return westernBahaiDate(WesternBahaiFromAbsolute(absoluteDate))
 	default:
+		if _, ok := dayCounts[calendar]; ok {
+			return dayCountDate(absoluteDate, calendar)
+		}
 		return Date{}
 	}
 }
 
+// DateFromComponents creates a Date of a given calendar from its components,
+// e.g. []float64{2022, 6, 15} for a Gregorian date. It returns an empty Date
+// if the calendar is not supported or the number of components does not match
+// the calendar.
+func DateFromComponents(calendar string, components []float64) Date {
+	d := Date{Calendar: calendar, Components: components}
+	n := len(components)
+	if c, ok := dayCounts[calendar]; ok {
+		absoluteDate := math.NaN()
+		if n == 1 {
+			absoluteDate = c.toAbsolute(components[0])
+		}
+		if math.IsNaN(absoluteDate) {
+			return Date{}
+		}
+		d = dayCountDate(absoluteDate, calendar)
+		d.Components = components
+		return d
+	}
+	switch {
+	case calendar == "mayanLongCount" && n == 5:
+		return mayanLongCountFromDate(d).Date()
+	case calendar == "mayanHaab" && n == 2:
+		return mayanHaabFromDate(d).Date()
+	case calendar == "mayanTzolkin" && n == 2:
+		return mayanTzolkinFromDate(d).Date()
+	case calendar == "aztecXihuitl" && n == 2:
+		return aztecXihuitlFromDate(d).Date()
+	case calendar == "aztecTonalpohualli" && n == 2:
+		return aztecTonalpohualliFromDate(d).Date()
+	case calendar == "roman" && n == 5:
+		return romanFromDate(d).Date()
+	case calendar == "baliPawukon" && n == 10:
+		return baliPawukonFromDate(d).Date()
+	case calendar == "hinduLunar" && (n == 3 || n == 5):
+		return hinduLunarFromDate(d).Date()
+	case calendar == "bahai" && (n == 3 || n == 5):
+		return bahaiFromDate(d).Date()
+	case calendar == "westernBahai" && (n == 3 || n == 5):
+		return westernBahaiDate(bahaiFromDate(d))
+	case n != 3:
+		return Date{}
+	}
+	switch calendar {
+	case "gregorian":
+		return gregorianFromDate(d).Date()
+	case "iso":
+		return isoFromDate(d).Date()
+	case "julian":
+		return julianFromDate(d).Date()
+	case "islamic":
+		return islamicFromDate(d).Date()
+	case "hebrew":
+		return hebrewFromDate(d).Date()
+	case "french":
+		return frenchFromDate(d).Date()
+	case "egyptian":
+		return egyptianFromDate(d).Date()
+	case "armenian":
+		return armenianFromDate(d).Date()
+	case "oldHinduSolar":
+		return oldHinduSolarFromDate(d).Date()
+	case "oldHinduLunar":
+		return oldHinduLunarFromDate(d).Date()
+	case "hinduSolar":
+		return hinduSolarFromDate(d).Date()
+	default:
+		return Date{}
+	}
+}
+
+// Errors returned by ValidateDate
+var (
+	// ErrUnknownCalendar is returned for dates of unsupported calendars.
+	ErrUnknownCalendar = errors.New("libcalendar: unknown calendar")
+	// ErrInvalidDate is returned for dates that do not exist in their
+	// calendar, e.g. 30 February.
+	ErrInvalidDate = errors.New("libcalendar: invalid date")
+)
+
+// maxComponent bounds the magnitude of the components of dates accepted by
+// ValidateDate, i.e. dates within about a million years, beyond which some
+// conversions lose precision or do not terminate.
+const maxComponent = 1e6
+
+// lastMonths gives the last month of the calendars whose second component is
+// the month. Dates with later months do not exist, and converting them may
+// index past the tables of month lengths.
+var lastMonths = map[string]float64{
+	"gregorian":     12,
+	"julian":        12,
+	"roman":         12,
+	"islamic":       12,
+	"hebrew":        13,
+	"french":        13,
+	"egyptian":      13,
+	"armenian":      13,
+	"oldHinduSolar": 12,
+	"oldHinduLunar": 12,
+	"hinduSolar":    12,
+	"hinduLunar":    12,
+}
+
+// ValidateDate checks whether a Date exists in its calendar. Dates of
+// calendars with absolute dates are valid if converting them to an absolute
+// date and back yields the same components; Mayan haab and tzolkin dates are
+// checked against the ranges of their components. Day counts are valid
+// unless they denote a non-existent date, and may be fractional. Components
+// beyond ±1,000,000 are invalid in every calendar but the day counts.
+func ValidateDate(d Date) error {
+	if !isValidCalendar(d.Calendar) {
+		return ErrUnknownCalendar
+	}
+	c := d.Components
+	_, isDayCount := dayCounts[d.Calendar]
+	if !isDayCount {
+		for _, x := range c {
+			if x != math.Floor(x) || math.Abs(x) > maxComponent {
+				return ErrInvalidDate
+			}
+		}
+		if last, ok := lastMonths[d.Calendar]; ok && len(c) > 1 && (c[1] < 1 || c[1] > last) {
+			return ErrInvalidDate
+		}
+	}
+	if DateFromComponents(d.Calendar, c).Calendar == "" {
+		return ErrInvalidDate
+	}
+	if isDayCount {
+		return nil
+	}
+	switch d.Calendar {
+	case "mayanHaab":
+		if c[1] < 1 || c[1] > 19 || c[0] < 0 || c[0] > 19 || (c[1] == 19 && c[0] > 4) {
+			return ErrInvalidDate
+		}
+		return nil
+	case "mayanTzolkin", "aztecTonalpohualli":
+		if c[0] < 1 || c[0] > 13 || c[1] < 1 || c[1] > 20 {
+			return ErrInvalidDate
+		}
+		return nil
+	case "baliPawukon":
+		b := baliPawukonFromDate(d)
+		if b.Pancawara < 1 || b.Pancawara > 5 || b.Sadwara < 1 || b.Sadwara > 6 ||
+			b.Saptawara < 1 || b.Saptawara > 7 || baliPawukonFromDay(b.day()) != b || (c[0] != 0 && c[0] != 1) {
+			return ErrInvalidDate
+		}
+		return nil
+	case "aztecXihuitl":
+		if c[1] < 1 || c[1] > 19 || c[0] < 1 || c[0] > 20 || (c[1] == 19 && c[0] > 5) {
+			return ErrInvalidDate
+		}
+		return nil
+	}
+	absoluteDate := AbsoluteFromDate(d)
+	if math.IsNaN(absoluteDate) {
+		return ErrInvalidDate
+	}
+	roundTrip := DateFromAbsolute(absoluteDate, d.Calendar)
+	if d.Calendar == "bahai" || d.Calendar == "westernBahai" {
+		// the year may be given by its number or by major cycle, cycle and
+		// year of the cycle
+		if bahaiFromDate(roundTrip) != bahaiFromDate(d) {
+			return ErrInvalidDate
+		}
+		return nil
+	}
+	for i := range c {
+		if roundTrip.Components[i] != c[i] {
+			return ErrInvalidDate
+		}
+	}
+	return nil
+}
+
 // JsonDateFromAbsolute converts a given absolute (fixed) date into the date
 // representation specified in `calendar`. It returns a Date marshalled into
 // a JSON-string.
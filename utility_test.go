@@ -17,6 +17,7 @@ package libcalendar_test
 
 import (
 	"fmt"
+	"testing"
 
 	lc "staudtlex.de/libcalendar"
 )
@@ -70,3 +71,80 @@ func Example() {
 			lc.AbsoluteFromDate(unmarshal_json_1), "mayanLongCount")
 	fmt.Println("Mayan long count: ", mayanLongCount)
 }
+
+func TestDateYear(t *testing.T) {
+	var tests = []struct {
+		calendar string
+		want     float64
+		ok       bool
+	}{
+		{"gregorian", 2022, true},
+		{"iso", 2022, true},
+		{"hebrew", 5782, true},
+		{"bahai", 179, true},
+		{"westernBahai", 179, true},
+		{"mayanHaab", 0, false},
+		{"julianDay", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.calendar, func(t *testing.T) {
+			ans, ok := lc.DateFromAbsolute(738321, tt.calendar).Year()
+			t.Logf("got %v %v, want %v %v", ans, ok, tt.want, tt.ok)
+			if ans != tt.want || ok != tt.ok {
+				t.Errorf("got %v %v, want %v %v", ans, ok, tt.want, tt.ok)
+			}
+		})
+	}
+	if ans, _ := (lc.Date{Calendar: "bahai", Components: []float64{179, 5, 11}}).Year(); ans != 179 {
+		t.Errorf("got %v, want %v", ans, 179)
+	}
+}
+
+func TestValidateDate(t *testing.T) {
+	var tests = []struct {
+		calendar   string
+		components []float64
+		want       error
+	}{
+		{"gregorian", []float64{2022, 6, 15}, nil},
+		{"gregorian", []float64{2022, 2, 29}, lc.ErrInvalidDate},
+		{"gregorian", []float64{2024, 2, 29}, nil},
+		{"gregorian", []float64{2022, 6}, lc.ErrInvalidDate},
+		{"gregorian", []float64{2022, 6, 1.5}, lc.ErrInvalidDate},
+		{"iso", []float64{2022, 53, 1}, lc.ErrInvalidDate},
+		{"hebrew", []float64{5782, 13, 29}, nil},
+		{"hebrew", []float64{5783, 13, 1}, lc.ErrInvalidDate},
+		{"islamic", []float64{1443, 11, 30}, nil},
+		{"islamic", []float64{1443, 11, 31}, lc.ErrInvalidDate},
+		{"french", []float64{230, 13, 6}, lc.ErrInvalidDate},
+		{"mayanLongCount", []float64{13, 0, 9, 11, 3}, nil},
+		{"mayanLongCount", []float64{13, 0, 9, 20, 3}, lc.ErrInvalidDate},
+		{"mayanHaab", []float64{4, 19}, nil},
+		{"mayanHaab", []float64{5, 19}, lc.ErrInvalidDate},
+		{"mayanTzolkin", []float64{13, 20}, nil},
+		{"mayanTzolkin", []float64{14, 1}, lc.ErrInvalidDate},
+		{"bahai", []float64{179, 5, 11}, nil},
+		{"bahai", []float64{1, 10, 8, 5, 11}, nil},
+		{"bahai", []float64{179, 5, 20}, lc.ErrInvalidDate},
+		{"bahai", []float64{1, 10, 20, 5, 11}, lc.ErrInvalidDate},
+		{"westernBahai", []float64{179, 5, 11}, nil},
+		{"westernBahai", []float64{179, 20, 1}, lc.ErrInvalidDate},
+		{"gregorian", []float64{2022, 14, 1}, lc.ErrInvalidDate},
+		{"julian", []float64{2022, 0, 1}, lc.ErrInvalidDate},
+		{"oldHinduLunar", []float64{5123, -1e7, 1}, lc.ErrInvalidDate},
+		{"hinduSolar", []float64{-1e15, 1, 1}, lc.ErrInvalidDate},
+		{"hebrew", []float64{1e15, 1, 1}, lc.ErrInvalidDate},
+		{"unixDay", []float64{1e15}, nil},
+		{"aztec", []float64{1, 1, 1}, lc.ErrUnknownCalendar},
+	}
+	for _, tt := range tests {
+		testname := fmt.Sprintf("%v %v", tt.calendar, tt.components)
+		t.Run(testname, func(t *testing.T) {
+			ans := lc.ValidateDate(lc.Date{Calendar: tt.calendar, Components: tt.components})
+			t.Logf("got %v, want %v", ans, tt.want)
+			if ans != tt.want {
+				t.Errorf("got %v, want %v", ans, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,331 @@
+// Copyright (C) 2022  Alexander Staudt
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package libcalendar
+
+import "math"
+
+// Weekday specifies a day of the week (Sunday = 0, ..., Saturday = 6), as
+// used by KDayOnOrBefore and NthKDay.
+type Weekday int
+
+// Days of the week
+const (
+	Sunday Weekday = iota
+	Monday
+	Tuesday
+	Wednesday
+	Thursday
+	Friday
+	Saturday
+)
+
+// NoWeekday is the Weekday of dates of calendars without weeks, e.g. the
+// Mayan calendars.
+const NoWeekday Weekday = -1
+
+// DayOfWeek returns the day of the week of a given absolute (fixed) date.
+func DayOfWeek(absoluteDate float64) Weekday {
+	return Weekday(mod(absoluteDate, 7))
+}
+
+// String returns the English name of the day.
+func (w Weekday) String() string {
+	return gregorianWeekdays[float64(w)]
+}
+
+// Gregorian (and Julian, ISO) weekdays
+var gregorianWeekdays = map[float64]string{
+	0: "Sunday",
+	1: "Monday",
+	2: "Tuesday",
+	3: "Wednesday",
+	4: "Thursday",
+	5: "Friday",
+	6: "Saturday",
+}
+
+// Roman weekdays, named after the planets
+var romanWeekdays = map[float64]string{
+	0: "dies Solis",
+	1: "dies Lunae",
+	2: "dies Martis",
+	3: "dies Mercurii",
+	4: "dies Iovis",
+	5: "dies Veneris",
+	6: "dies Saturni",
+}
+
+// Hebrew weekdays
+var hebrewWeekdays = map[float64]string{
+	0: "Yom Rishon",
+	1: "Yom Sheni",
+	2: "Yom Shlishi",
+	3: "Yom Revi'i",
+	4: "Yom Hamishi",
+	5: "Yom Shishi",
+	6: "Shabbat",
+}
+
+// Islamic weekdays
+var islamicWeekdays = map[float64]string{
+	0: "al-Ahad",
+	1: "al-Ithnayn",
+	2: "ath-Thulatha",
+	3: "al-Arbi'a",
+	4: "al-Khamis",
+	5: "al-Jum'a",
+	6: "as-Sabt",
+}
+
+// Hindu weekdays (vara)
+var hinduWeekdays = map[float64]string{
+	0: "Ravivara",
+	1: "Somavara",
+	2: "Mangalavara",
+	3: "Budhavara",
+	4: "Guruvara",
+	5: "Shukravara",
+	6: "Shanivara",
+}
+
+// Bahá'í weekdays, the week beginning on Saturday (Jalál)
+var bahaiWeekdays = map[float64]string{
+	0: "Jamál",
+	1: "Kamál",
+	2: "Fiḍál",
+	3: "ʻIdál",
+	4: "Istijlál",
+	5: "Istiqlál",
+	6: "Jalál",
+}
+
+// French Revolutionary days of the décade
+var frenchDecadeDays = map[float64]string{
+	1:  "Primidi",
+	2:  "Duodi",
+	3:  "Tridi",
+	4:  "Quartidi",
+	5:  "Quintidi",
+	6:  "Sextidi",
+	7:  "Septidi",
+	8:  "Octidi",
+	9:  "Nonidi",
+	10: "Décadi",
+}
+
+// French Revolutionary complementary days (sansculottides)
+var frenchSansculottides = map[float64]string{
+	1: "Jour de la vertu",
+	2: "Jour du génie",
+	3: "Jour du travail",
+	4: "Jour de l'opinion",
+	5: "Jour des récompenses",
+	6: "Jour de la révolution",
+}
+
+// DayOfDecade returns the day (1-10) of the décade, the ten-day week of the
+// French Revolutionary calendar. The sansculottides do not belong to any
+// décade, for them DayOfDecade returns 0.
+func (d FrenchDate) DayOfDecade() float64 {
+	if d.Month == 13 {
+		return 0
+	}
+	return amod(d.Day, 10)
+}
+
+// weekdayKey returns the calendar and key under which the name of the day
+// of a given absolute date is stored in a Locale's Weekdays. For calendars
+// without weeks, it returns an empty calendar name.
+func weekdayKey(calendar string, absoluteDate float64) (string, float64) {
+	if math.IsNaN(absoluteDate) {
+		return "", 0
+	}
+	switch calendar {
+	case "gregorian", "julian", "iso":
+		return "gregorian", float64(DayOfWeek(absoluteDate))
+	case "hebrew", "islamic", "roman":
+		return calendar, float64(DayOfWeek(absoluteDate))
+	case "oldHinduSolar", "oldHinduLunar", "hinduSolar", "hinduLunar":
+		return "hindu", float64(DayOfWeek(absoluteDate))
+	case "bahai", "westernBahai":
+		return "bahai", float64(DayOfWeek(absoluteDate))
+	case "french":
+		d := FrenchFromAbsolute(absoluteDate)
+		if d.Month == 13 {
+			return "frenchSansculottides", d.Day
+		}
+		return "french", d.DayOfDecade()
+	default:
+		return "", 0
+	}
+}
+
+// LocalizedWeekdayName returns the name of the day of the week of a given
+// absolute date in the calendar and locale specified. The French
+// Revolutionary calendar names the days of its décade and the sansculottides.
+// The Mayan calendars have no weeks, for them an empty string is returned.
+func LocalizedWeekdayName(calendar string, absoluteDate float64, tag string) string {
+	calendar, key := weekdayKey(calendar, absoluteDate)
+	if calendar == "" {
+		return ""
+	}
+	name, _ := lookupName(tag, key, func(l Locale) map[float64]string {
+		return l.Weekdays[calendar]
+	})
+	return name
+}
+
+// weekdayName returns the name of a day of the week, in the root locale, from
+// a given table of weekday names (see weekdayKey).
+func weekdayName(table string, w Weekday) string {
+	name, _ := lookupName(RootLocale, float64(w), func(l Locale) map[float64]string {
+		return l.Weekdays[table]
+	})
+	return name
+}
+
+// Weekday returns the day of the week of its receiver.
+func (d GregorianDate) Weekday() Weekday { return DayOfWeek(AbsoluteFromGregorian(d)) }
+
+// Weekday returns the day of the week of its receiver.
+func (d JulianDate) Weekday() Weekday { return DayOfWeek(AbsoluteFromJulian(d)) }
+
+// Weekday returns the day of the week of its receiver.
+func (d RomanDate) Weekday() Weekday { return DayOfWeek(AbsoluteFromRoman(d)) }
+
+// Weekday returns the day of the week of its receiver.
+func (d IsoDate) Weekday() Weekday { return Weekday(mod(d.Day, 7)) }
+
+// Weekday returns the day of the week of its receiver.
+func (d IslamicDate) Weekday() Weekday { return DayOfWeek(AbsoluteFromIslamic(d)) }
+
+// Weekday returns the day of the week of its receiver.
+func (d HebrewDate) Weekday() Weekday { return DayOfWeek(AbsoluteFromHebrew(d)) }
+
+// Weekday returns the day of the week of its receiver.
+func (d FrenchDate) Weekday() Weekday { return DayOfWeek(AbsoluteFromFrench(d)) }
+
+// Weekday returns the day of the week of its receiver.
+func (d OldHinduSolarDate) Weekday() Weekday { return DayOfWeek(AbsoluteFromOldHinduSolar(d)) }
+
+// Weekday returns the day of the week of its receiver.
+func (d OldHinduLunarDate) Weekday() Weekday { return DayOfWeek(AbsoluteFromOldHinduLunar(d)) }
+
+// Weekday returns the day of the week of its receiver.
+func (d HinduSolarDate) Weekday() Weekday { return DayOfWeek(AbsoluteFromHinduSolar(d)) }
+
+// Weekday returns the day of the week of its receiver.
+func (d HinduLunarDate) Weekday() Weekday { return DayOfWeek(AbsoluteFromHinduLunar(d)) }
+
+// Weekday returns the day of the week of its receiver.
+func (d BahaiDate) Weekday() Weekday { return DayOfWeek(AbsoluteFromBahai(d)) }
+
+// Weekday returns the day of the 7-day week (saptawara) of its receiver, from
+// Redite (Sunday) to Saniscara (Saturday).
+func (d BaliPawukonDate) Weekday() Weekday { return Weekday(d.Saptawara - 1) }
+
+// Weekday returns NoWeekday, as the Egyptian calendar has no weeks.
+func (d EgyptianDate) Weekday() Weekday { return NoWeekday }
+
+// Weekday returns NoWeekday, as the Armenian calendar has no weeks.
+func (d ArmenianDate) Weekday() Weekday { return NoWeekday }
+
+// Weekday returns NoWeekday, as the Mayan calendars have no weeks.
+func (d MayanLongCount) Weekday() Weekday { return NoWeekday }
+
+// Weekday returns NoWeekday, as the Mayan calendars have no weeks.
+func (d MayanHaabDate) Weekday() Weekday { return NoWeekday }
+
+// Weekday returns NoWeekday, as the Mayan calendars have no weeks.
+func (d MayanTzolkinDate) Weekday() Weekday { return NoWeekday }
+
+// Weekday returns NoWeekday, as the Aztec calendars have no weeks.
+func (d AztecXihuitlDate) Weekday() Weekday { return NoWeekday }
+
+// Weekday returns NoWeekday, as the Aztec calendars have no weeks.
+func (d AztecTonalpohualliDate) Weekday() Weekday { return NoWeekday }
+
+// WeekdayName returns the name of the day of the week of its receiver.
+func (d GregorianDate) WeekdayName() string { return weekdayName("gregorian", d.Weekday()) }
+
+// WeekdayName returns the name of the day of the week of its receiver.
+func (d JulianDate) WeekdayName() string { return weekdayName("gregorian", d.Weekday()) }
+
+// WeekdayName returns the name of the day of the week of its receiver, e.g.
+// "dies Solis".
+func (d RomanDate) WeekdayName() string { return weekdayName("roman", d.Weekday()) }
+
+// WeekdayName returns the name of the day of the week of its receiver.
+func (d IsoDate) WeekdayName() string { return weekdayName("gregorian", d.Weekday()) }
+
+// WeekdayName returns the name of the day of the week of its receiver, e.g.
+// "al-Jum'a".
+func (d IslamicDate) WeekdayName() string { return weekdayName("islamic", d.Weekday()) }
+
+// WeekdayName returns the name of the day of the week of its receiver, e.g.
+// "Yom Rishon".
+func (d HebrewDate) WeekdayName() string { return weekdayName("hebrew", d.Weekday()) }
+
+// WeekdayName returns the name of the day of the décade of its receiver, e.g.
+// "Primidi", or the name of the sansculottide. Unlike Weekday, it does not
+// refer to the 7-day week, which the calendar abolished.
+func (d FrenchDate) WeekdayName() string {
+	if d.Month == 13 {
+		return frenchSansculottides[d.Day]
+	}
+	return frenchDecadeDays[d.DayOfDecade()]
+}
+
+// WeekdayName returns the name of the day of the week of its receiver.
+func (d OldHinduSolarDate) WeekdayName() string { return weekdayName("hindu", d.Weekday()) }
+
+// WeekdayName returns the name of the day of the week of its receiver.
+func (d OldHinduLunarDate) WeekdayName() string { return weekdayName("hindu", d.Weekday()) }
+
+// WeekdayName returns the name of the day of the week of its receiver.
+func (d HinduSolarDate) WeekdayName() string { return weekdayName("hindu", d.Weekday()) }
+
+// WeekdayName returns the name of the day of the week of its receiver.
+func (d HinduLunarDate) WeekdayName() string { return weekdayName("hindu", d.Weekday()) }
+
+// WeekdayName returns the name of the day of the week of its receiver, e.g.
+// "Jalál".
+func (d BahaiDate) WeekdayName() string { return weekdayName("bahai", d.Weekday()) }
+
+// WeekdayName returns the name of the day of the 7-day week (saptawara) of
+// its receiver.
+func (d BaliPawukonDate) WeekdayName() string { return baliSaptawara[float64(d.Weekday())+1] }
+
+// WeekdayName returns an empty string, as the Egyptian calendar has no weeks.
+func (d EgyptianDate) WeekdayName() string { return "" }
+
+// WeekdayName returns an empty string, as the Armenian calendar has no weeks.
+func (d ArmenianDate) WeekdayName() string { return "" }
+
+// WeekdayName returns an empty string, as the Mayan calendars have no weeks.
+func (d MayanLongCount) WeekdayName() string { return "" }
+
+// WeekdayName returns an empty string, as the Mayan calendars have no weeks.
+func (d MayanHaabDate) WeekdayName() string { return "" }
+
+// WeekdayName returns an empty string, as the Mayan calendars have no weeks.
+func (d MayanTzolkinDate) WeekdayName() string { return "" }
+
+// WeekdayName returns an empty string, as the Aztec calendars have no weeks.
+func (d AztecXihuitlDate) WeekdayName() string { return "" }
+
+// WeekdayName returns an empty string, as the Aztec calendars have no weeks.
+func (d AztecTonalpohualliDate) WeekdayName() string { return "" }
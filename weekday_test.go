@@ -0,0 +1,117 @@
+// Copyright (C) 2022  Alexander Staudt
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package libcalendar
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestDayOfWeek(t *testing.T) {
+	for i, rd := range dates.Rd {
+		want := Weekday(mod(dates.Iso[i].Day, 7))
+		testname := fmt.Sprintf("%.0f", rd)
+		t.Run(testname, func(t *testing.T) {
+			got := DayOfWeek(rd)
+			if got != want {
+				t.Errorf("got %v, want %v", got, want)
+			}
+			if g := dates.Hebrew[i].Weekday(); g != want {
+				t.Errorf("Hebrew: got %v, want %v", g, want)
+			}
+			if g := dates.Gregorian[i].Weekday(); g != want {
+				t.Errorf("Gregorian: got %v, want %v", g, want)
+			}
+		})
+	}
+}
+
+func TestWeekdayName(t *testing.T) {
+	tests := []struct {
+		got  string
+		want string
+	}{
+		{GregorianDate{2022, 6, 15}.WeekdayName(), "Wednesday"},
+		{HebrewDate{5782, sivan, 16}.WeekdayName(), "Yom Revi'i"},
+		{HebrewDate{5782, sivan, 19}.WeekdayName(), "Shabbat"},
+		{IslamicDate{1443, dhuAlQada, 15}.WeekdayName(), "al-Arbi'a"},
+		{FrenchDate{230, prairial, 27}.WeekdayName(), "Septidi"},
+		{FrenchDate{230, 13, 6}.WeekdayName(), "Jour de la révolution"},
+		{MayanLongCount{13, 0, 9, 11, 14}.WeekdayName(), ""},
+		{LocalizedWeekdayName("hebrew", 738321, "he"), "יום רביעי"},
+		{LocalizedWeekdayName("islamic", 738323, "ar"), "الجمعة"},
+		{LocalizedWeekdayName("french", 738321, "fr"), "septidi"},
+		{LocalizedWeekdayName("mayanHaab", 738321, "fr"), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			t.Logf("got %v, want %v", tt.got, tt.want)
+			if tt.got != tt.want {
+				t.Errorf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWeekday(t *testing.T) {
+	// Wednesday, 15 June 2022
+	const rd = 738321
+	tests := []struct {
+		date interface {
+			Weekday() Weekday
+			WeekdayName() string
+		}
+		want Weekday
+		name string
+	}{
+		{JulianFromAbsolute(rd), Wednesday, "Wednesday"},
+		{RomanFromAbsolute(rd), Wednesday, "dies Mercurii"},
+		{IsoFromAbsolute(rd), Wednesday, "Wednesday"},
+		{OldHinduSolarFromAbsolute(rd), Wednesday, "Budhavara"},
+		{OldHinduLunarFromAbsolute(rd), Wednesday, "Budhavara"},
+		{HinduSolarFromAbsolute(rd), Wednesday, "Budhavara"},
+		{HinduLunarFromAbsolute(rd), Wednesday, "Budhavara"},
+		{BahaiFromAbsolute(rd), Wednesday, "ʻIdál"},
+		{BaliPawukonFromAbsolute(rd), Wednesday, "Buda"},
+		{BaliPawukonFromAbsolute(rd + 4), Sunday, "Redite"},
+		{EgyptianFromAbsolute(rd), NoWeekday, ""},
+		{ArmenianFromAbsolute(rd), NoWeekday, ""},
+		{MayanHaabFromAbsolute(rd), NoWeekday, ""},
+		{MayanTzolkinFromAbsolute(rd), NoWeekday, ""},
+		{AztecXihuitlFromAbsolute(rd), NoWeekday, ""},
+		{AztecTonalpohualliFromAbsolute(rd), NoWeekday, ""},
+	}
+
+	for _, tt := range tests {
+		testname := fmt.Sprintf("%T", tt.date)
+		t.Run(testname, func(t *testing.T) {
+			got, name := tt.date.Weekday(), tt.date.WeekdayName()
+			t.Logf("got %v %q, want %v %q", got, name, tt.want, tt.name)
+			if got != tt.want || name != tt.name {
+				t.Errorf("got %v %q, want %v %q", got, name, tt.want, tt.name)
+			}
+		})
+	}
+}
+
+func TestDateJsonWeekday(t *testing.T) {
+	json := DateFromAbsolute(738321, "hebrew").Localize("he").Json()
+	if !strings.Contains(json, `"weekday":"יום רביעי"`) {
+		t.Errorf("got %v, want weekday יום רביעי", json)
+	}
+}